@@ -0,0 +1,297 @@
+package gmap
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode determines how a Decimal is rounded to fewer digits.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, and ties to the even neighbour, e.g. 2.5 to 2 and 3.5 to 4.
+	// This is the default.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, and ties away from zero, e.g. 2.5 to 3 and -2.5 to -3.
+	RoundHalfUp
+	// RoundDown rounds towards zero, e.g. 2.9 to 2 and -2.9 to -2.
+	RoundDown
+	// RoundUp rounds away from zero, e.g. 2.1 to 3 and -2.1 to -3.
+	RoundUp
+	// RoundFloor rounds towards negative infinity, e.g. 2.9 to 2 and -2.1 to -3.
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity, e.g. 2.1 to 3 and -2.9 to -2.
+	RoundCeiling
+)
+
+// Decimal is a fixed-point decimal number, such as a price or a balance, that does not suffer from binary rounding.
+// It is stored as an integer number of units and a scale, so 19.99 is 1999 units with a scale of 2.
+// The zero value is 0.
+type Decimal struct {
+	units *big.Int
+	scale int
+}
+
+// DecimalOptions configures how DecimalWithOptions reads a Decimal.
+type DecimalOptions struct {
+	// Scale is the number of digits after the decimal point, e.g. 2 for cents.
+	Scale int
+	// Rounding determines how values with more digits are rounded. Defaults to RoundHalfEven.
+	Rounding RoundingMode
+}
+
+var bigTen = big.NewInt(10)
+
+// Limits the exponent ParseDecimal accepts, so that '1e999999999' cannot be used to exhaust memory.
+const maxDecimalExponent = 1000
+
+// Returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// Creates a Decimal from an integer number of units and a scale, e.g. NewDecimal(1999, 2) is 19.99.
+// A negative scale multiplies the units, e.g. NewDecimal(5, -2) is 500.
+func NewDecimal(units int64, scale int) Decimal {
+	if scale < 0 {
+		return Decimal{units: new(big.Int).Mul(big.NewInt(units), pow10(-scale))}
+	}
+	return Decimal{units: big.NewInt(units), scale: scale}
+}
+
+// Parses a decimal number such as '19.99', '-0.5' or '1.5e3' exactly.
+// The scale is the number of digits after the decimal point, so '19.90' has a scale of 2.
+func ParseDecimal(s string) (Decimal, error) {
+	syntaxError := &strconv.NumError{Func: "ParseDecimal", Num: s, Err: strconv.ErrSyntax}
+
+	mantissa, exponent := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Decimal{}, syntaxError
+		}
+		if e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, ErrOverflow
+		}
+		mantissa, exponent = s[:i], e
+	}
+
+	sign := ""
+	if len(mantissa) > 0 && (mantissa[0] == '-' || mantissa[0] == '+') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+
+	whole, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		whole, fraction = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := whole + fraction
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, syntaxError
+	}
+
+	units, _ := new(big.Int).SetString(sign+digits, 10)
+	scale := len(fraction) - exponent
+	if scale < 0 {
+		units.Mul(units, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{units: units, scale: scale}, nil
+}
+
+// Returns the units of d, treating the zero value as 0.
+func (d Decimal) bigUnits() *big.Int {
+	if d.units == nil {
+		return new(big.Int)
+	}
+	return d.units
+}
+
+// Returns the integer number of units of d, e.g. 1999 for 19.99.
+// The result is a new *big.Int, which can be modified without changing d.
+func (d Decimal) Units() *big.Int {
+	return new(big.Int).Set(d.bigUnits())
+}
+
+// Returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Returns d with the given number of digits after the decimal point, rounding as specified by mode.
+// A negative scale is treated as 0.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+
+	units := d.bigUnits()
+	if scale >= d.scale {
+		return Decimal{units: new(big.Int).Mul(units, pow10(scale-d.scale)), scale: scale}
+	}
+
+	divisor := pow10(d.scale - scale)
+	q, r := new(big.Int).QuoRem(units, divisor, new(big.Int))
+	if r.Sign() == 0 {
+		return Decimal{units: q, scale: scale}
+	}
+
+	// compare the remainder with half of the divisor
+	half := new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(divisor)
+	sign := units.Sign()
+
+	away := false
+	switch mode {
+	case RoundHalfEven:
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	case RoundHalfUp:
+		away = half >= 0
+	case RoundUp:
+		away = true
+	case RoundFloor:
+		away = sign < 0
+	case RoundCeiling:
+		away = sign > 0
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+	return Decimal{units: q, scale: scale}
+}
+
+// Brings a and b to the same scale without rounding.
+func alignDecimals(a, b Decimal) (*big.Int, *big.Int, int) {
+	if a.scale < b.scale {
+		return a.Round(b.scale, RoundDown).units, b.bigUnits(), b.scale
+	}
+	return a.bigUnits(), b.Round(a.scale, RoundDown).units, a.scale
+}
+
+// Returns d + other, with the larger of their scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := alignDecimals(d, other)
+	return Decimal{units: new(big.Int).Add(a, b), scale: scale}
+}
+
+// Returns d - other, with the larger of their scales.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := alignDecimals(d, other)
+	return Decimal{units: new(big.Int).Sub(a, b), scale: scale}
+}
+
+// Returns d * other, with the sum of their scales.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{units: new(big.Int).Mul(d.bigUnits(), other.bigUnits()), scale: d.scale + other.scale}
+}
+
+// Returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{units: new(big.Int).Neg(d.bigUnits()), scale: d.scale}
+}
+
+// Compares d and other, regardless of their scales.
+// Returns -1 if d < other, 0 if d == other, and +1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := alignDecimals(d, other)
+	return a.Cmp(b)
+}
+
+// Returns -1 if d < 0, 0 if d == 0, and +1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.bigUnits().Sign()
+}
+
+// Returns the nearest float64 to d.
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.bigUnits(), pow10(d.scale)).Float64()
+	return f
+}
+
+// Formats d with exactly Scale() digits after the decimal point, e.g. '19.90'.
+func (d Decimal) String() string {
+	units := d.bigUnits()
+	digits := new(big.Int).Abs(units).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+
+	if units.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON writes d as a JSON number with all of its digits.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON reads d from a JSON number or string without rounding.
+// A JSON null leaves d unchanged, the same as for other types in encoding/json.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Converts v to a Decimal, the same way as the Decimal getter.
+// Useful in Reduce, e.g. to add up amounts of different types.
+func ToDecimal(v interface{}) (Decimal, error) {
+	return interfaceToDecimal(v, Decimal{})
+}
+
+// ReduceFunc that adds up the values of a Map as Decimals, starting from a Decimal memo,
+// e.g. m.Reduce(Decimal{}, SumDecimals).(Decimal).
+// Nil values are skipped. Panics with a *Error if any other value cannot be converted,
+// so that a bad amount is never left out of a total silently. Use Map.SumDecimals to get an error instead.
+func SumDecimals(memo interface{}, k string, v interface{}) interface{} {
+	if v == nil {
+		return memo
+	}
+
+	d, err := ToDecimal(v)
+	if err != nil {
+		panic(newValueError(k, "gmap.Decimal", v, err))
+	}
+	return memo.(Decimal).Add(d)
+}
+
+// Adds up the values of the map as Decimals, skipping nil values.
+// Returns a *Error for the first key, in sorted order, whose value cannot be converted.
+func (m Map) SumDecimals() (Decimal, error) {
+	keys := m.Keys()
+	sort.Strings(keys)
+
+	var sum Decimal
+	for _, k := range keys {
+		v := m[k]
+		if v == nil {
+			continue
+		}
+
+		d, err := ToDecimal(v)
+		if err != nil {
+			return Decimal{}, newValueError(k, "gmap.Decimal", v, err)
+		}
+		sum = sum.Add(d)
+	}
+	return sum, nil
+}
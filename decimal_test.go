@@ -0,0 +1,167 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for s, expected := range map[string]string{
+		"19.99":   "19.99",
+		"19.90":   "19.90",
+		"-0.5":    "-0.5",
+		"+3":      "3",
+		".5":      "0.5",
+		"1.5e3":   "1500",
+		"1.5E-2":  "0.015",
+		"0.00001": "0.00001",
+	} {
+		d, err := ParseDecimal(s)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, d.String())
+	}
+
+	for _, s := range []string{"", "-", "abc", "1.2.3", "1e", "1e2.5", "--1", "1,000"} {
+		_, err := ParseDecimal(s)
+		assert.NotNil(t, err)
+	}
+
+	_, err := ParseDecimal("1e999999999")
+	assert.Equal(t, ErrOverflow, err)
+}
+
+func TestDecimalRound(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.5", RoundHalfEven, "2"},
+		{"3.5", RoundHalfEven, "4"},
+		{"-2.5", RoundHalfEven, "-2"},
+		{"2.51", RoundHalfEven, "3"},
+		{"2.5", RoundHalfUp, "3"},
+		{"-2.5", RoundHalfUp, "-3"},
+		{"2.49", RoundHalfUp, "2"},
+		{"2.9", RoundDown, "2"},
+		{"-2.9", RoundDown, "-2"},
+		{"2.1", RoundUp, "3"},
+		{"-2.1", RoundUp, "-3"},
+		{"2.9", RoundFloor, "2"},
+		{"-2.1", RoundFloor, "-3"},
+		{"2.1", RoundCeiling, "3"},
+		{"-2.9", RoundCeiling, "-2"},
+	} {
+		d, _ := ParseDecimal(c.value)
+		assert.Equal(t, c.expected, d.Round(0, c.mode).String())
+	}
+
+	d, _ := ParseDecimal("1.005")
+	assert.Equal(t, "1.00", d.Round(2, RoundHalfEven).String())
+	assert.Equal(t, "1.01", d.Round(2, RoundHalfUp).String())
+	assert.Equal(t, "1.00500", d.Round(5, RoundHalfEven).String())
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, _ := ParseDecimal("19.99")
+	b, _ := ParseDecimal("0.015")
+
+	assert.Equal(t, "20.005", a.Add(b).String())
+	assert.Equal(t, "19.975", a.Sub(b).String())
+	assert.Equal(t, "39.98", a.Mul(NewDecimal(2, 0)).String())
+	assert.Equal(t, "-19.99", a.Neg().String())
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, 0, NewDecimal(150, 2).Cmp(NewDecimal(15, 1)))
+	assert.Equal(t, -1, a.Neg().Sign())
+	assert.Equal(t, 0, Decimal{}.Sign())
+	assert.Equal(t, "0", Decimal{}.String())
+	assert.Equal(t, "500", NewDecimal(5, -2).String())
+	assert.Equal(t, 19.99, a.Float64())
+	assert.Equal(t, big.NewInt(1999), a.Units())
+	assert.Equal(t, 2, a.Scale())
+
+	// 0.1 + 0.2 is exactly 0.3
+	sum := NewDecimal(1, 1).Add(NewDecimal(2, 1))
+	assert.Equal(t, 0, sum.Cmp(NewDecimal(3, 1)))
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var dst struct {
+		Price  Decimal `json:"price"`
+		Amount Decimal `json:"amount"`
+	}
+
+	err := json.Unmarshal([]byte(`{"price": 19.99, "amount": "12345678901234567890.01"}`), &dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "19.99", dst.Price.String())
+	assert.Equal(t, "12345678901234567890.01", dst.Amount.String())
+
+	data, err := json.Marshal(Map{"price": dst.Price})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"price":19.99}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"price": null}`), &dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "19.99", dst.Price.String())
+}
+
+func TestToDecimal(t *testing.T) {
+	for v, expected := range map[interface{}]string{
+		19.99:                        "19.99",
+		float32(0.1):                 "0.1",
+		42:                           "42",
+		uint64(18446744073709551615): "18446744073709551615",
+		"0.30":                       "0.30",
+		json.Number("1.10"):          "1.10",
+	} {
+		d, err := ToDecimal(v)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, d.String())
+	}
+
+	d, err := ToDecimal(big.NewRat(3, 8))
+	assert.Nil(t, err)
+	assert.Equal(t, "0.375", d.String())
+
+	d, err = ToDecimal(big.NewRat(-7, 50))
+	assert.Nil(t, err)
+	assert.Equal(t, "-0.14", d.String())
+
+	_, err = ToDecimal(big.NewRat(1, 3))
+	assert.Equal(t, ErrPrecisionLoss, err)
+
+	d, err = ToDecimal(big.NewInt(7))
+	assert.Nil(t, err)
+	assert.Equal(t, "7", d.String())
+
+	_, err = ToDecimal(true)
+	assert.Equal(t, ErrTypeMismatch, err)
+}
+
+func TestSumDecimals(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{"a": "0.10", "b": 0.2, "c": json.Number("1.005"), "d": nil}
+	total := gmap.Reduce(Decimal{}, SumDecimals).(Decimal)
+	assert.Equal(t, "1.305", total.String())
+
+	total, err := gmap.SumDecimals()
+	assert.Nil(t, err)
+	assert.Equal(t, "1.305", total.String())
+
+	gmap["e"] = "ten"
+	_, err = gmap.SumDecimals()
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	assert.Equal(t, "e", err.(*Error).Key)
+
+	defer func() {
+		assert.True(t, errors.Is(recover().(error), strconv.ErrSyntax))
+	}()
+	gmap.Reduce(Decimal{}, SumDecimals)
+	t.Error("SumDecimals did not panic")
+}
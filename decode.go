@@ -0,0 +1,361 @@
+package gmap
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+	decimalType  = reflect.TypeOf(Decimal{})
+)
+
+// DecodeError lists every field that could not be decoded.
+// Each entry is an *Error whose Key is the full path of the field within the Map if its value could not be converted,
+// or a *PathError whose Path is the full path if the value is missing, nil or out of range.
+type DecodeError struct {
+	Errors []error
+}
+
+func (e *DecodeError) Error() string {
+	return formatErrors("gmap decode failed", e.Errors)
+}
+
+// Unwrap returns the errors of every failed field, so errors.Is matches any of them.
+func (e *DecodeError) Unwrap() []error {
+	return e.Errors
+}
+
+// Options specified in a struct field tag, e.g. `gmap:"name,omitempty,required"`.
+type fieldTag struct {
+	name      string
+	omitEmpty bool
+	required  bool
+}
+
+// Looks up the gmap tag of a struct field, falling back to its json tag.
+func lookupFieldTag(field reflect.StructField) (string, bool) {
+	if tag, ok := field.Tag.Lookup("gmap"); ok {
+		return tag, true
+	}
+	return field.Tag.Lookup("json")
+}
+
+// Reads the gmap or json tag of a struct field.
+// The name defaults to the field name, and is "-" for fields that should be skipped.
+func parseFieldTag(field reflect.StructField) fieldTag {
+	tag, hasTag := lookupFieldTag(field)
+	parts := strings.Split(tag, ",")
+	ft := fieldTag{name: parts[0]}
+	if !hasTag || ft.name == "" {
+		ft.name = field.Name
+	}
+
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "omitempty":
+			ft.omitEmpty = true
+		case "required":
+			ft.required = true
+		}
+	}
+	return ft
+}
+
+// Returns true if an anonymous struct field should have its fields promoted into the parent.
+func isEmbeddedStruct(field reflect.StructField) bool {
+	if !field.Anonymous {
+		return false
+	}
+	if tag, hasTag := lookupFieldTag(field); hasTag && strings.Split(tag, ",")[0] != "" {
+		return false
+	}
+
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// Appends a key to a path, using brackets if the key would otherwise be ambiguous.
+func joinPath(path, key string) string {
+	if strings.ContainsAny(key, ".[]") || key == "" {
+		return path + "[" + key + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Finds a key in the map, preferring an exact match over a case-insensitive one.
+func findKey(mp Map, name string) (string, interface{}, bool) {
+	if value, ok := mp[name]; ok {
+		return name, value, true
+	}
+
+	for k, v := range mp {
+		if strings.EqualFold(k, name) {
+			return k, v, true
+		}
+	}
+	return name, nil, false
+}
+
+type decoder struct {
+	errs []error
+}
+
+func (d *decoder) fail(path, segment string, err error) {
+	d.errs = append(d.errs, &PathError{Path: path, Segment: segment, Err: err})
+}
+
+// Records a value, found at path, that cannot be converted to the type of rv.
+func (d *decoder) failValue(path string, rv reflect.Value, value interface{}, err error) {
+	d.errs = append(d.errs, newValueError(path, rv.Type().String(), value, err))
+}
+
+// Fills a struct using the values in the map, which is found at path.
+func (d *decoder) decodeStruct(path string, mp Map, rv reflect.Value) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if isEmbeddedStruct(field) {
+			if fv.Kind() == reflect.Ptr {
+				if !fv.CanSet() {
+					continue
+				}
+				if fv.IsNil() {
+					fv.Set(reflect.New(field.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			d.decodeStruct(path, mp, fv)
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		ft := parseFieldTag(field)
+		if ft.name == "-" {
+			continue
+		}
+
+		key, value, ok := findKey(mp, ft.name)
+		fieldPath := joinPath(path, key)
+		if !ok {
+			if ft.required {
+				d.fail(fieldPath, key, ErrKeyDoesNotExist)
+			}
+			continue
+		}
+
+		if value == nil {
+			if ft.required {
+				d.fail(fieldPath, key, ErrNilValue)
+			}
+			continue
+		}
+
+		d.decodeValue(fieldPath, key, value, fv)
+	}
+}
+
+// Converts value, which is found at path, and stores it in rv.
+func (d *decoder) decodeValue(path, segment string, value interface{}, rv reflect.Value) {
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		d.decodeValue(path, segment, value, rv.Elem())
+		return
+	}
+
+	if rv.Type() == timeType {
+		t, err := interfaceToTime(value, time.Time{})
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.Set(reflect.ValueOf(t))
+		return
+	}
+
+	if rv.Type() == durationType {
+		// plain numbers are seconds, the same as the Duration getter
+		dur, err := interfaceToDuration(value, 0, time.Second)
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.SetInt(int64(dur))
+		return
+	}
+
+	if rv.Type() == bigIntType {
+		b, err := interfaceToBigInt(value, nil)
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.Set(reflect.ValueOf(b).Elem())
+		return
+	}
+
+	if rv.Type() == bigFloatType {
+		f, err := interfaceToBigFloat(value, nil)
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.Set(reflect.ValueOf(f).Elem())
+		return
+	}
+
+	if rv.Type() == decimalType {
+		dec, err := interfaceToDecimal(value, Decimal{})
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.Set(reflect.ValueOf(dec))
+		return
+	}
+
+	vv := reflect.ValueOf(value)
+	if vv.Type().AssignableTo(rv.Type()) {
+		rv.Set(vv)
+		return
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		b, err := interfaceToBool(value, false)
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := interfaceToInt64(value, 0)
+		if err == nil && rv.OverflowInt(i) {
+			err = ErrOverflow
+		}
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := interfaceToUint64(value, 0)
+		if err == nil && rv.OverflowUint(u) {
+			err = ErrOverflow
+		}
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := interfaceToFloat64(value, 0.0)
+		if err == nil && rv.OverflowFloat(f) {
+			err = ErrTypeMismatch
+		}
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.SetFloat(f)
+
+	case reflect.String:
+		s, err := interfaceToString(value, "")
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		rv.SetString(s)
+
+	case reflect.Struct:
+		mp, err := interfaceToMap(value, nil)
+		if err != nil {
+			d.failValue(path, rv, value, err)
+			return
+		}
+		d.decodeStruct(path, mp, rv)
+
+	case reflect.Map:
+		mp, err := interfaceToMap(value, nil)
+		if err != nil || rv.Type().Key().Kind() != reflect.String {
+			d.failValue(path, rv, value, ErrTypeMismatch)
+			return
+		}
+		result := reflect.MakeMapWithSize(rv.Type(), len(mp))
+		for k, v := range mp {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if v != nil {
+				d.decodeValue(joinPath(path, k), k, v, elem)
+			}
+			result.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(result)
+
+	case reflect.Slice, reflect.Array:
+		if vv.Kind() != reflect.Slice && vv.Kind() != reflect.Array {
+			d.failValue(path, rv, value, ErrTypeMismatch)
+			return
+		}
+		length := vv.Len()
+		if rv.Kind() == reflect.Slice {
+			rv.Set(reflect.MakeSlice(rv.Type(), length, length))
+		} else if length > rv.Len() {
+			d.fail(path, segment, ErrIndexOutOfRange)
+			return
+		}
+		for i := 0; i < length; i++ {
+			v := vv.Index(i).Interface()
+			if v != nil {
+				index := strconv.Itoa(i)
+				d.decodeValue(path+"["+index+"]", index, v, rv.Index(i))
+			}
+		}
+
+	default:
+		d.failValue(path, rv, value, ErrTypeMismatch)
+	}
+}
+
+// Fills the struct pointed to by dst with values from the map.
+// Fields are matched by their `gmap:"name"` tag, their `json:"name"` tag, or their field name,
+// preferring exact matches over case-insensitive ones.
+// The tag option 'required' reports missing or nil values as errors, and a name of '-' skips the field.
+// Values are converted the same way as the getters, including nested structs, slices, maps, pointers and time.Time.
+// Integer fields are converted exactly, the same way as Int64 and Uint64, so values that do not fit the field
+// report ErrOverflow and values with a fractional part report ErrPrecisionLoss.
+// time.Duration fields are read the same way as Duration, so plain numbers such as 30 are seconds.
+// Returns a *DecodeError listing every field that failed, after decoding all the fields it could.
+func (m Map) Decode(dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidDecodeTarget
+	}
+
+	d := &decoder{}
+	d.decodeStruct("", m, rv.Elem())
+	if len(d.errs) > 0 {
+		return &DecodeError{Errors: d.errs}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDecodePayload = `
+{
+ "name": "John",
+ "Age": "42",
+ "score": 97.5,
+ "active": "true",
+ "created": "Mon, 10 Jul 2017 12:13:47 GMT",
+ "tags": ["a", "b", 3],
+ "counts": { "apples": "2", "pears": 3 },
+ "address": { "street": "123 Main St", "zip": 94105 },
+ "previous": [
+  { "street": "1 Old Rd", "zip": "89101" }
+ ],
+ "nickname": "Johnny",
+ "ignored": "value",
+ "Extra": { "anything": true },
+ "nothing": null
+}
+`
+
+type testAddress struct {
+	Street string `gmap:"street"`
+	Zip    int    `gmap:"zip"`
+}
+
+type testAudit struct {
+	Created time.Time `gmap:"created"`
+}
+
+type testPerson struct {
+	testAudit
+	Name     string         `gmap:"name,required"`
+	Age      int8           `gmap:",omitempty"`
+	Score    float32        `gmap:"score"`
+	Active   bool           `gmap:"active"`
+	Tags     []string       `gmap:"tags"`
+	Counts   map[string]int `gmap:"counts"`
+	Address  testAddress    `gmap:"address"`
+	Previous []*testAddress `gmap:"previous"`
+	Nickname *string        `gmap:"nickname"`
+	Ignored  string         `gmap:"-"`
+	Extra    Map
+	Nothing  string `gmap:"nothing"`
+	secret   string
+}
+
+func TestDecode(t *testing.T) {
+	var gmap Map
+	var err error
+	var person testPerson
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testDecodePayload), &gmap)
+	assert.Nil(t, err)
+
+	person.Nothing = "unchanged"
+	err = gmap.Decode(&person)
+	assert.Nil(t, err)
+	assert.Equal(t, "John", person.Name)
+	assert.Equal(t, int8(42), person.Age)
+	assert.Equal(t, float32(97.5), person.Score)
+	assert.Equal(t, true, person.Active)
+	assert.Equal(t, 2017, person.Created.Year())
+	assert.Equal(t, []string{"a", "b", "3"}, person.Tags)
+	assert.Equal(t, map[string]int{"apples": 2, "pears": 3}, person.Counts)
+	assert.Equal(t, testAddress{Street: "123 Main St", Zip: 94105}, person.Address)
+	assert.Equal(t, 1, len(person.Previous))
+	assert.Equal(t, testAddress{Street: "1 Old Rd", Zip: 89101}, *person.Previous[0])
+	assert.Equal(t, "Johnny", *person.Nickname)
+	assert.Equal(t, "", person.Ignored)
+	assert.Equal(t, Map{"anything": true}, person.Extra)
+	assert.Equal(t, "unchanged", person.Nothing)
+	assert.Equal(t, "", person.secret)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var gmap Map
+	var err error
+	var person testPerson
+
+	gmap = Map{
+		"Age":      1000,
+		"score":    "high",
+		"address":  Map{"zip": "nine"},
+		"previous": []interface{}{Map{"street": []string{}}},
+		"tags":     "not an array",
+	}
+
+	err = gmap.Decode(&person)
+	assert.NotNil(t, err)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	paths := []string{}
+	for _, e := range err.(*DecodeError).Errors {
+		switch e.(type) {
+		case *PathError:
+			paths = append(paths, e.(*PathError).Path)
+		case *Error:
+			paths = append(paths, e.(*Error).Key)
+		}
+	}
+	assert.Contains(t, paths, "name")
+	assert.Contains(t, paths, "Age")
+	assert.Contains(t, paths, "score")
+	assert.Contains(t, paths, "address.zip")
+	assert.Contains(t, paths, "previous[0].street")
+	assert.Contains(t, paths, "tags")
+	assert.Equal(t, 6, len(paths))
+	assert.NotContains(t, err.Error(), "gmap path \"score\"")
+	assert.Contains(t, err.Error(), `gmap key "score": cannot convert string "high" to float32`)
+
+	gmap = Map{"name": nil}
+	err = gmap.Decode(&person)
+	assert.True(t, errors.Is(err, ErrNilValue))
+
+	err = gmap.Decode(person)
+	assert.Equal(t, ErrInvalidDecodeTarget, err)
+
+	err = gmap.Decode((*testPerson)(nil))
+	assert.Equal(t, ErrInvalidDecodeTarget, err)
+}
+
+func TestDecodeBigNumbers(t *testing.T) {
+	var dst struct {
+		ID      *big.Int  `gmap:"id"`
+		Total   big.Int   `gmap:"total"`
+		Amount  big.Float `gmap:"amount"`
+		Count   int64     `gmap:"count"`
+		Missing *big.Int  `gmap:"missing"`
+	}
+
+	err := Map{
+		"id":     json.Number("123456789012345678901234567890"),
+		"total":  "42",
+		"amount": json.Number("19.99"),
+		"count":  json.Number("7"),
+	}.Decode(&dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012345678901234567890", dst.ID.String())
+	assert.Equal(t, int64(42), dst.Total.Int64())
+	assert.Equal(t, "19.99", dst.Amount.Text('f', 2))
+	assert.Equal(t, int64(7), dst.Count)
+	assert.Nil(t, dst.Missing)
+
+	err = Map{"id": 1.5}.Decode(&dst)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+}
+
+func TestDecodeExactIntegers(t *testing.T) {
+	var dst struct {
+		Signed   int64  `gmap:"signed"`
+		Count    int    `gmap:"count"`
+		Unsigned uint64 `gmap:"unsigned"`
+		Small    int8   `gmap:"small"`
+		Port     uint16 `gmap:"port"`
+	}
+
+	err := Map{
+		"signed":   json.Number("-9223372036854775808"),
+		"count":    3.0,
+		"unsigned": json.Number("18446744073709551615"),
+		"small":    "-128",
+		"port":     uint64(8080),
+	}.Decode(&dst)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MinInt64), dst.Signed)
+	assert.Equal(t, 3, dst.Count)
+	assert.Equal(t, uint64(math.MaxUint64), dst.Unsigned)
+	assert.Equal(t, int8(-128), dst.Small)
+	assert.Equal(t, uint16(8080), dst.Port)
+
+	err = Map{"signed": uint64(math.MaxUint64)}.Decode(&dst)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	err = Map{"count": 2.9}.Decode(&dst)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	err = Map{"small": 128}.Decode(&dst)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	err = Map{"port": -1}.Decode(&dst)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	err = Map{"port": 70000}.Decode(&dst)
+	assert.True(t, errors.Is(err, ErrOverflow))
+}
+
+func TestDecodeDecimal(t *testing.T) {
+	var dst struct {
+		Price Decimal `gmap:"price"`
+	}
+
+	err := Map{"price": 19.99}.Decode(&dst)
+	assert.Nil(t, err)
+	assert.Equal(t, "19.99", dst.Price.String())
+
+	mp, err := FromStruct(dst)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"price": dst.Price}, mp)
+}
+
+func TestDecodeDuration(t *testing.T) {
+	var dst struct {
+		Timeout  time.Duration `gmap:"timeout"`
+		Interval time.Duration `gmap:"interval"`
+		Raw      time.Duration `gmap:"raw"`
+	}
+
+	gmap := Map{"timeout": "30s", "interval": "PT5M", "raw": 30}
+	err := gmap.Decode(&dst)
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, dst.Timeout)
+	assert.Equal(t, 5*time.Minute, dst.Interval)
+
+	// plain numbers are read the same way as the Duration getter
+	raw, _ := gmap.Duration("raw", 0)
+	assert.Equal(t, 30*time.Second, dst.Raw)
+	assert.Equal(t, raw, dst.Raw)
+
+	err = Map{"raw": 1500 * time.Millisecond}.Decode(&dst)
+	assert.Nil(t, err)
+	assert.Equal(t, 1500*time.Millisecond, dst.Raw)
+}
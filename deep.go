@@ -0,0 +1,16 @@
+package gmap
+
+// DeepCopy returns a copy of the map that shares nothing with the original.
+// Nested Maps, map[string]interface{}, map[interface{}]interface{}, slices and arrays are copied recursively.
+// Other values, including time.Time, are copied by value.
+func (m Map) DeepCopy() Map {
+	return deepCopy(m).(Map)
+}
+
+// Compares two Maps, recursing into nested Maps and arrays.
+// By default values must be of the same type to be equal, except that Map, map[string]interface{}
+// and map[interface{}]interface{} are compared by their contents.
+// Options such as WithNumericTypeInsensitive and WithFloatTolerance loosen the comparison.
+func DeepEqual(a, b Map, opts ...CompareOption) bool {
+	return newComparer(opts).equal(a, b)
+}
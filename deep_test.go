@@ -0,0 +1,64 @@
+package gmap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepCopy(t *testing.T) {
+	now := time.Now()
+	original := Map{
+		"nested":  Map{"list": []interface{}{Map{"a": 1}}},
+		"plain":   map[string]interface{}{"b": 2},
+		"yaml":    map[interface{}]interface{}{1: "one"},
+		"strings": []string{"x", "y"},
+		"counts":  map[string]int{"c": 3},
+		"time":    now,
+		"nil":     []interface{}(nil),
+	}
+
+	cp := original.DeepCopy()
+	assert.Equal(t, original, cp)
+
+	cp["nested"].(Map)["list"].([]interface{})[0].(Map)["a"] = 100
+	cp["plain"].(map[string]interface{})["b"] = 200
+	cp["yaml"].(map[interface{}]interface{})[1] = "uno"
+	cp["strings"].([]string)[0] = "z"
+	cp["counts"].(map[string]int)["c"] = 300
+
+	assert.Equal(t, 1, original["nested"].(Map)["list"].([]interface{})[0].(Map)["a"])
+	assert.Equal(t, 2, original["plain"].(map[string]interface{})["b"])
+	assert.Equal(t, "one", original["yaml"].(map[interface{}]interface{})[1])
+	assert.Equal(t, []string{"x", "y"}, original["strings"])
+	assert.Equal(t, map[string]int{"c": 3}, original["counts"])
+	assert.Equal(t, now, cp["time"])
+	assert.Nil(t, cp["nil"])
+	assert.Nil(t, Map(nil).DeepCopy())
+}
+
+func TestDeepEqual(t *testing.T) {
+	a := Map{
+		"nested": map[string]interface{}{"list": []interface{}{1, 2.5}},
+		"name":   "John",
+	}
+	b := Map{
+		"nested": Map{"list": []interface{}{1, 2.5}},
+		"name":   "John",
+	}
+	c := Map{
+		"nested": Map{"list": []interface{}{1.0, 2.5000001}},
+		"name":   "John",
+	}
+
+	assert.True(t, DeepEqual(a, b))
+	assert.False(t, DeepEqual(a, c))
+	assert.False(t, DeepEqual(a, c, WithNumericTypeInsensitive()))
+	assert.False(t, DeepEqual(a, c, WithFloatTolerance(0.001)))
+	assert.True(t, DeepEqual(a, c, WithNumericTypeInsensitive(), WithFloatTolerance(0.001)))
+
+	assert.False(t, DeepEqual(Map{"n": "1"}, Map{"n": 1}, WithNumericTypeInsensitive()))
+	assert.True(t, DeepEqual(Map{"n": "1"}, Map{"n": 1}, WithCoercion()))
+	assert.False(t, DeepEqual(Map{"n": 1}, Map{"n": 1, "m": nil}))
+}
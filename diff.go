@@ -0,0 +1,244 @@
+package gmap
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// ChangeType describes how a value differs between two Maps.
+type ChangeType int
+
+const (
+	// ChangeAdded is a value that only exists in the new Map.
+	ChangeAdded ChangeType = iota
+	// ChangeRemoved is a value that only exists in the old Map.
+	ChangeRemoved
+	// ChangeModified is a value that exists in both Maps but is different.
+	ChangeModified
+)
+
+func (ct ChangeType) String() string {
+	switch ct {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return "ChangeType(" + strconv.Itoa(int(ct)) + ")"
+	}
+}
+
+// Change is a single difference found by Diff.
+// Path is written the same way as the path getters, e.g. 'user.addresses[0].zip', and Segments holds its parts.
+// OldValue is nil for added values, and NewValue is nil for removed values.
+type Change struct {
+	Type     ChangeType
+	Path     string
+	Segments []string
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// CompareOption configures how values are compared.
+type CompareOption func(*comparer)
+
+type comparer struct {
+	coerce    bool
+	numeric   bool
+	tolerance float64
+}
+
+// Considers values equal if they convert to the same float64, e.g. "100" and 100.
+// Uses the same conversion rules as Float.
+func WithCoercion() CompareOption {
+	return func(c *comparer) {
+		c.coerce = true
+	}
+}
+
+// Considers numbers of different types equal if they have the same value, e.g. int(1) and float64(1).
+// Unlike WithCoercion, strings and booleans are not converted.
+func WithNumericTypeInsensitive() CompareOption {
+	return func(c *comparer) {
+		c.numeric = true
+	}
+}
+
+// Considers numbers equal if they differ by no more than tolerance.
+// Numbers of different types are only compared this way together with WithNumericTypeInsensitive or WithCoercion.
+func WithFloatTolerance(tolerance float64) CompareOption {
+	return func(c *comparer) {
+		c.tolerance = tolerance
+	}
+}
+
+func newComparer(opts []CompareOption) *comparer {
+	c := &comparer{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Compares two values that are not both Maps or both arrays.
+func (c *comparer) equalValues(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+
+	numbers := isNumber(a) && isNumber(b) && (c.numeric || reflect.TypeOf(a) == reflect.TypeOf(b))
+	if numbers && c.tolerance == 0 {
+		return equalNumbers(a, b)
+	}
+	if c.coerce || numbers {
+		fa, errA := interfaceToFloat64(a, 0.0)
+		fb, errB := interfaceToFloat64(b, 0.0)
+		if errA == nil && errB == nil && math.Abs(fa-fb) <= c.tolerance {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compares two numbers exactly, so that large integers such as int64 IDs above 2^53 are not rounded to the same float64.
+func equalNumbers(a, b interface{}) bool {
+	ra, okA := numberToRat(a)
+	rb, okB := numberToRat(b)
+	if !okA || !okB {
+		// infinities and NaN
+		fa, _ := interfaceToFloat64(a, 0.0)
+		fb, _ := interfaceToFloat64(b, 0.0)
+		return fa == fb
+	}
+	return ra.Cmp(rb) == 0
+}
+
+// Converts a number to an exact *big.Rat. Returns false for infinities, NaN and values that are not numbers.
+func numberToRat(v interface{}) (*big.Rat, bool) {
+	if isNilBig(v) {
+		return nil, false
+	}
+
+	switch v.(type) {
+	case Decimal:
+		d := v.(Decimal)
+		return new(big.Rat).SetFrac(d.bigUnits(), pow10(d.scale)), true
+	case *big.Rat:
+		return v.(*big.Rat), true
+	}
+
+	f, err := interfaceToBigFloat(v, nil)
+	if err != nil || f.IsInf() {
+		return nil, false
+	}
+	r, _ := f.Rat(nil)
+	return r, true
+}
+
+// Returns true if two values are equal, recursing into nested Maps and arrays.
+func (c *comparer) equal(a, b interface{}) bool {
+	return len(c.diff("", nil, a, b, nil)) == 0
+}
+
+// Returns true if v is an integer or floating point number, including json.Number and big numbers.
+// A nil *big.Int, *big.Float or *big.Rat is not a number.
+func isNumber(v interface{}) bool {
+	if isNilBig(v) {
+		return false
+	}
+
+	switch v.(type) {
+	case json.Number, *big.Int, *big.Float, *big.Rat, Decimal:
+		return true
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Appends the differences between two values found at path.
+func (c *comparer) diff(path string, segments []string, a, b interface{}, changes []Change) []Change {
+	aMap, aErr := interfaceToMap(a, nil)
+	bMap, bErr := interfaceToMap(b, nil)
+	if aErr == nil && bErr == nil {
+		return c.diffMaps(path, segments, aMap, bMap, changes)
+	}
+
+	aArray, aErr := interfaceToArray(a, nil)
+	bArray, bErr := interfaceToArray(b, nil)
+	if aErr == nil && bErr == nil {
+		return c.diffArrays(path, segments, aArray, bArray, changes)
+	}
+
+	if !c.equalValues(a, b) {
+		changes = append(changes, Change{Type: ChangeModified, Path: path, Segments: segments, OldValue: a, NewValue: b})
+	}
+	return changes
+}
+
+func (c *comparer) diffMaps(path string, segments []string, a, b Map, changes []Change) []Change {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := joinPath(path, k)
+		childSegments := append(segments[:len(segments):len(segments)], k)
+		aValue, inA := a[k]
+		bValue, inB := b[k]
+		switch {
+		case !inA:
+			changes = append(changes, Change{Type: ChangeAdded, Path: childPath, Segments: childSegments, NewValue: bValue})
+		case !inB:
+			changes = append(changes, Change{Type: ChangeRemoved, Path: childPath, Segments: childSegments, OldValue: aValue})
+		default:
+			changes = c.diff(childPath, childSegments, aValue, bValue, changes)
+		}
+	}
+	return changes
+}
+
+func (c *comparer) diffArrays(path string, segments []string, a, b []interface{}, changes []Change) []Change {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		index := strconv.Itoa(i)
+		childPath := path + "[" + index + "]"
+		childSegments := append(segments[:len(segments):len(segments)], index)
+		switch {
+		case i >= len(a):
+			changes = append(changes, Change{Type: ChangeAdded, Path: childPath, Segments: childSegments, NewValue: b[i]})
+		case i >= len(b):
+			changes = append(changes, Change{Type: ChangeRemoved, Path: childPath, Segments: childSegments, OldValue: a[i]})
+		default:
+			changes = c.diff(childPath, childSegments, a[i], b[i], changes)
+		}
+	}
+	return changes
+}
+
+// Compares two Maps, recursing into nested Maps and arrays.
+// Returns the added, removed and modified values, sorted by key, or an empty slice if the Maps are equal.
+// Array elements are compared by index, so elements beyond the length of the other array are added or removed.
+func Diff(a, b Map, opts ...CompareOption) []Change {
+	c := newComparer(opts)
+	return c.diffMaps("", nil, a, b, make([]Change, 0))
+}
@@ -0,0 +1,112 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	var a Map
+	var b Map
+
+	a = Map{
+		"name":  "John",
+		"age":   41,
+		"email": "john@example.com",
+		"address": Map{
+			"zip":  "94105",
+			"city": "San Francisco",
+		},
+		"tags":  []interface{}{"a", Map{"b": 1}, "c"},
+		"same":  map[string]interface{}{"x": 1},
+		"price": "100",
+	}
+
+	b = Map{
+		"name": "John",
+		"age":  42,
+		"address": map[string]interface{}{
+			"zip":  "94107",
+			"city": "San Francisco",
+		},
+		"tags":  []interface{}{"a", Map{"b": 2}},
+		"same":  Map{"x": 1},
+		"phone": "555-1234",
+		"price": 100,
+	}
+
+	changes := Diff(a, b)
+	assert.Equal(t, []Change{
+		{Type: ChangeModified, Path: "address.zip", Segments: []string{"address", "zip"}, OldValue: "94105", NewValue: "94107"},
+		{Type: ChangeModified, Path: "age", Segments: []string{"age"}, OldValue: 41, NewValue: 42},
+		{Type: ChangeRemoved, Path: "email", Segments: []string{"email"}, OldValue: "john@example.com"},
+		{Type: ChangeAdded, Path: "phone", Segments: []string{"phone"}, NewValue: "555-1234"},
+		{Type: ChangeModified, Path: "price", Segments: []string{"price"}, OldValue: "100", NewValue: 100},
+		{Type: ChangeModified, Path: "tags[1].b", Segments: []string{"tags", "1", "b"}, OldValue: 1, NewValue: 2},
+		{Type: ChangeRemoved, Path: "tags[2]", Segments: []string{"tags", "2"}, OldValue: "c"},
+	}, changes)
+
+	changes = Diff(a, b, WithCoercion())
+	assert.Equal(t, 6, len(changes))
+	for _, change := range changes {
+		assert.NotEqual(t, "price", change.Path)
+	}
+
+	assert.Equal(t, []Change{}, Diff(a, a))
+	assert.Equal(t, "added", ChangeAdded.String())
+	assert.Equal(t, "removed", ChangeRemoved.String())
+	assert.Equal(t, "modified", ChangeModified.String())
+}
+
+func TestDiffTypeChange(t *testing.T) {
+	a := Map{"value": Map{"nested": true}, "list": []interface{}{1}}
+	b := Map{"value": "flat", "list": "none"}
+
+	changes := Diff(a, b)
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, ChangeModified, changes[0].Type)
+	assert.Equal(t, "list", changes[0].Path)
+	assert.Equal(t, Map{"nested": true}, changes[1].OldValue)
+	assert.Equal(t, "flat", changes[1].NewValue)
+}
+
+func TestDiffNumbers(t *testing.T) {
+	a := Map{"id": json.Number("42"), "big": big.NewInt(7)}
+	b := Map{"id": 42, "big": 7.0}
+
+	assert.Len(t, Diff(a, b), 2)
+	assert.Empty(t, Diff(a, b, WithNumericTypeInsensitive()))
+}
+
+func TestDiffNilBigNumbers(t *testing.T) {
+	a := Map{"n": (*big.Int)(nil), "r": (*big.Rat)(nil)}
+
+	assert.True(t, DeepEqual(a, Map{"n": (*big.Int)(nil), "r": (*big.Rat)(nil)}, WithNumericTypeInsensitive()))
+	assert.False(t, DeepEqual(a, Map{"n": 0, "r": (*big.Rat)(nil)}, WithNumericTypeInsensitive()))
+	assert.False(t, DeepEqual(a, Map{"n": (*big.Int)(nil), "r": big.NewRat(0, 1)}, WithNumericTypeInsensitive()))
+	assert.Len(t, Diff(a, Map{"n": big.NewInt(0), "r": 0.0}, WithNumericTypeInsensitive(), WithCoercion()), 2)
+}
+
+func TestDiffLargeIntegers(t *testing.T) {
+	// both round to the same float64
+	a := Map{"id": int64(9007199254740993)}
+	b := Map{"id": int64(9007199254740992)}
+
+	assert.False(t, DeepEqual(a, b))
+	assert.False(t, DeepEqual(a, b, WithNumericTypeInsensitive()))
+	assert.Len(t, Diff(a, b), 1)
+	assert.Len(t, CreatePatch(a, b), 1)
+	assert.Equal(t, Map{"id": int64(9007199254740992)}, CreateMergePatch(a, b))
+
+	_, err := a.ApplyPatch([]PatchOperation{{Op: PatchTest, Path: "/id", Value: int64(9007199254740992)}})
+	assert.True(t, errors.Is(err, ErrTestFailed))
+
+	_, err = a.ApplyPatch([]PatchOperation{{Op: PatchTest, Path: "/id", Value: json.Number("9007199254740993")}})
+	assert.Nil(t, err)
+
+	assert.True(t, DeepEqual(Map{"n": NewDecimal(150, 2)}, Map{"n": 1.5}, WithNumericTypeInsensitive()))
+}
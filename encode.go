@@ -0,0 +1,216 @@
+package gmap
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// The predeclared types that values of named types such as `type Status string` are converted to.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Uintptr: reflect.TypeOf(uintptr(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+}
+
+type encoder struct {
+	timeFormat string
+}
+
+// Returns true if the value should be left out of a Map because of the 'omitempty' tag option.
+// Follows encoding/json, except that a zero time.Time is also considered empty.
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Bool:
+		return !rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return rv.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return rv.IsNil()
+	case reflect.Struct:
+		return rv.Type() == timeType && rv.Interface().(time.Time).IsZero()
+	}
+	return false
+}
+
+// Converts the exported fields of a struct into a Map, which is found at path.
+func (e *encoder) encodeStruct(path string, rv reflect.Value) (Map, error) {
+	mp := Map{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if isEmbeddedStruct(field) {
+			if fv.Kind() == reflect.Ptr {
+				if field.PkgPath != "" || fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			embedded, err := e.encodeStruct(path, fv)
+			if err != nil {
+				return nil, err
+			}
+			// fields of the outer struct take precedence over promoted fields
+			for k, v := range embedded {
+				if _, ok := mp[k]; !ok {
+					mp[k] = v
+				}
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		ft := parseFieldTag(field)
+		if ft.name == "-" || (ft.omitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+
+		fieldPath := joinPath(path, ft.name)
+		value, err := e.encodeValue(fieldPath, ft.name, fv)
+		if err != nil {
+			return nil, err
+		}
+		mp[ft.name] = value
+	}
+
+	return mp, nil
+}
+
+// Converts a value, which is found at path, into plain values, Maps and []interface{}.
+func (e *encoder) encodeValue(path, segment string, rv reflect.Value) (interface{}, error) {
+	if rv.Type() == timeType {
+		t := rv.Interface().(time.Time)
+		if e.timeFormat == "" {
+			return t, nil
+		}
+		// the ISO8601 layout ends with a literal Z, so it is only correct for UTC
+		if e.timeFormat == TimeFormatISO8601 {
+			t = t.UTC()
+		}
+		return t.Format(e.timeFormat), nil
+	}
+
+	// big numbers and Decimals are kept as numbers instead of being encoded as structs,
+	// and durations and json.Number keep their type, as the getters read them differently from their underlying type
+	switch rv.Type() {
+	case durationType, reflect.TypeOf(json.Number("")):
+		return rv.Interface(), nil
+	case bigIntType:
+		b := rv.Interface().(big.Int)
+		return new(big.Int).Set(&b), nil
+	case bigFloatType:
+		f := rv.Interface().(big.Float)
+		return new(big.Float).Copy(&f), nil
+	case decimalType:
+		return rv.Interface(), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return e.encodeValue(path, segment, rv.Elem())
+
+	case reflect.Struct:
+		return e.encodeStruct(path, rv)
+
+	case reflect.Map:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		mp := Map{}
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, err := interfaceToString(iter.Key().Interface(), "")
+			if err != nil {
+				return nil, &PathError{Path: path, Segment: segment, Err: err}
+			}
+			v, err := e.encodeValue(joinPath(path, k), k, iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			mp[k] = v
+		}
+		return mp, nil
+
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Interface(), nil
+		}
+		arr := make([]interface{}, rv.Len())
+		for i := range arr {
+			index := strconv.Itoa(i)
+			v, err := e.encodeValue(path+"["+index+"]", index, rv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = v
+		}
+		return arr, nil
+
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return nil, &PathError{Path: path, Segment: segment, Err: ErrTypeMismatch}
+
+	default:
+		// named types such as `type Status string` become their underlying type, so the getters can read them
+		if t, ok := basicTypes[rv.Kind()]; ok {
+			return rv.Convert(t).Interface(), nil
+		}
+		return rv.Interface(), nil
+	}
+}
+
+// Creates a Map from the exported fields of a struct, or a pointer to a struct.
+// Fields are named by their `gmap:"name"` tag, their `json:"name"` tag, or their field name.
+// The tag option 'omitempty' leaves out empty values, and a name of '-' skips the field.
+// Fields of embedded structs are promoted into the Map, unless the embedded field is tagged.
+// Nested structs and maps become Maps, slices and arrays become []interface{}, and time.Time values are kept as they are.
+// Values of named types, such as `type Status string`, are converted to their underlying type, except for time.Duration.
+func FromStruct(v interface{}) (Map, error) {
+	return FromStructWithTimeFormat(v, "")
+}
+
+// Creates a Map from a struct the same way as FromStruct, but formats time.Time values as strings using layout,
+// e.g. TimeFormatRFC1123, so they can be read back by Time and Decode.
+// An empty layout keeps time.Time values as they are.
+func FromStructWithTimeFormat(v interface{}, layout string) (Map, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+
+	e := &encoder{timeFormat: layout}
+	return e.encodeStruct("", rv)
+}
@@ -0,0 +1,145 @@
+package gmap
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBase struct {
+	ID      int       `json:"id"`
+	Created time.Time `json:"created"`
+}
+
+type testItem struct {
+	Name  string  `gmap:"name"`
+	Price float64 `json:"price,omitempty"`
+}
+
+type testOrder struct {
+	testBase
+	Customer string            `gmap:"customer"`
+	Items    []testItem        `gmap:"items"`
+	Labels   map[string]string `gmap:"labels,omitempty"`
+	Notes    *string           `gmap:"notes"`
+	Discount float64           `gmap:"discount,omitempty"`
+	Shipped  time.Time         `gmap:"shipped,omitempty"`
+	Internal string            `gmap:"-"`
+	Raw      []byte
+	Untagged bool
+	secret   string
+}
+
+func TestFromStruct(t *testing.T) {
+	created := time.Date(2017, time.July, 10, 12, 13, 47, 0, time.UTC)
+	order := testOrder{
+		testBase: testBase{ID: 7, Created: created},
+		Customer: "John",
+		Items:    []testItem{{Name: "apple", Price: 0.5}, {Name: "gift"}},
+		Internal: "hidden",
+		Raw:      []byte("raw"),
+		secret:   "hidden",
+	}
+
+	mp, err := FromStruct(&order)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{
+		"id":       7,
+		"created":  created,
+		"customer": "John",
+		"items": []interface{}{
+			Map{"name": "apple", "price": 0.5},
+			Map{"name": "gift"},
+		},
+		"notes":    nil,
+		"Raw":      []byte("raw"),
+		"Untagged": false,
+	}, mp)
+
+	order.Labels = map[string]string{"color": "red"}
+	mp, err = FromStructWithTimeFormat(order, TimeFormatRFC1123)
+	assert.Nil(t, err)
+	assert.Equal(t, "Mon, 10 Jul 2017 12:13:47 UTC", mp["created"])
+	assert.Equal(t, Map{"color": "red"}, mp["labels"])
+
+	_, err = FromStruct(Map{})
+	assert.Equal(t, ErrNotStruct, err)
+
+	_, err = FromStruct(struct{ Fn func() }{})
+	assert.NotNil(t, err)
+	assert.Equal(t, "Fn", err.(*PathError).Path)
+}
+
+func TestFromStructRoundTrip(t *testing.T) {
+	created := time.Date(2017, time.July, 10, 12, 13, 47, 0, time.UTC)
+	order := testOrder{
+		testBase: testBase{ID: 7, Created: created},
+		Customer: "John",
+		Items:    []testItem{{Name: "apple", Price: 0.5}},
+	}
+
+	mp, err := FromStructWithTimeFormat(order, TimeFormatISO8601)
+	assert.Nil(t, err)
+
+	var decoded testOrder
+	err = mp.Decode(&decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, order.ID, decoded.ID)
+	assert.Equal(t, order.Customer, decoded.Customer)
+	assert.Equal(t, order.Items, decoded.Items)
+	assert.Equal(t, created, decoded.Created)
+}
+
+func TestFromStructBigNumbers(t *testing.T) {
+	src := struct {
+		ID     *big.Int
+		Amount big.Float
+		None   *big.Int
+	}{
+		ID:     big.NewInt(42),
+		Amount: *big.NewFloat(1.5),
+	}
+
+	mp, err := FromStruct(src)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(42), mp["ID"])
+	assert.Equal(t, "1.5", mp["Amount"].(*big.Float).Text('f', -1))
+	assert.Nil(t, mp["None"])
+
+	mp["ID"].(*big.Int).SetInt64(1)
+	assert.Equal(t, int64(42), src.ID.Int64())
+}
+
+func TestFromStructNamedTypes(t *testing.T) {
+	type level int8
+	src := struct {
+		Status  testStatus    `gmap:"status"`
+		Level   level         `gmap:"level"`
+		Timeout time.Duration `gmap:"timeout"`
+		Tags    []testStatus  `gmap:"tags"`
+	}{
+		Status:  "active",
+		Level:   3,
+		Timeout: 5 * time.Second,
+		Tags:    []testStatus{"a"},
+	}
+
+	mp, err := FromStruct(src)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{
+		"status":  "active",
+		"level":   int8(3),
+		"timeout": 5 * time.Second,
+		"tags":    []interface{}{"a"},
+	}, mp)
+
+	status, err := mp.String("status", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "active", status)
+
+	timeout, err := mp.Duration("timeout", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Second, timeout)
+}
@@ -2,6 +2,9 @@ package gmap
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 // ErrTypeMismatch is returned when gmap is not able to convert the underlying value to the type specified.
@@ -10,8 +13,206 @@ var ErrTypeMismatch = errors.New("gmap value type mismatch")
 // ErrElementTypeMismatch is returned when one of the elements of the underlying value has a different type.
 var ErrElementTypeMismatch = errors.New("gmap elements type mismatch")
 
+// ErrOverflow is returned when a number does not fit in the type specified, such as a negative number for uint64.
+var ErrOverflow = errors.New("gmap value overflows type")
+
+// ErrPrecisionLoss is returned when a number has a fractional part but an integer type is specified.
+var ErrPrecisionLoss = errors.New("gmap value loses precision")
+
+// ErrInvalidTime is returned when a string is not a time in a recognized format.
+var ErrInvalidTime = errors.New("gmap invalid time")
+
+// ErrInvalidDuration is returned when a string is not a duration in a recognized format.
+var ErrInvalidDuration = errors.New("gmap invalid duration")
+
 // ErrKeyDoesNotExist is returned when the specified key does not exist.
 var ErrKeyDoesNotExist = errors.New("gmap key does not exist")
 
 // ErrNilValue is returned when the underlying value is nil.
 var ErrNilValue = errors.New("gmap value is nil")
+
+// ErrIndexOutOfRange is returned when an array index in a path is outside the bounds of the array.
+var ErrIndexOutOfRange = errors.New("gmap index out of range")
+
+// ErrInvalidPath is returned when a path cannot be parsed.
+var ErrInvalidPath = errors.New("gmap invalid path")
+
+// ErrInvalidPointer is returned when a JSON Pointer cannot be parsed.
+var ErrInvalidPointer = errors.New("gmap invalid json pointer")
+
+// ErrInvalidDecodeTarget is returned when Decode is not given a non-nil pointer to a struct.
+var ErrInvalidDecodeTarget = errors.New("gmap decode target must be a non-nil pointer to a struct")
+
+// ErrNotStruct is returned when a struct, or a pointer to a struct, is expected but not given.
+var ErrNotStruct = errors.New("gmap value is not a struct")
+
+// ErrKeyConflict is returned when a key is given both a value and nested keys, or is given more than once.
+var ErrKeyConflict = errors.New("gmap conflicting values for key")
+
+// ErrTooDeep is returned when a form key is nested deeper than allowed.
+var ErrTooDeep = errors.New("gmap key nested too deeply")
+
+// ErrTooManyKeys is returned when there are more form keys than allowed.
+var ErrTooManyKeys = errors.New("gmap too many keys")
+
+// ErrBodyTooLarge is returned when a request body is longer than allowed.
+var ErrBodyTooLarge = errors.New("gmap request body too large")
+
+// ErrTrailingData is returned when a JSON request body has more data after its value.
+var ErrTrailingData = errors.New("gmap unexpected data after json value")
+
+// ErrUnsupportedContentType is returned when a request body has a Content-Type that cannot be read.
+var ErrUnsupportedContentType = errors.New("gmap unsupported content type")
+
+// ErrMergeConflict is returned when a merge finds different values at a path that must not conflict.
+var ErrMergeConflict = errors.New("gmap merge conflict")
+
+// ErrInvalidPatch is returned when a patch operation is unknown or malformed.
+var ErrInvalidPatch = errors.New("gmap invalid patch operation")
+
+// ErrTestFailed is returned when a patch test operation finds a different value.
+var ErrTestFailed = errors.New("gmap patch test failed")
+
+// PathError records which segment of a path failed to resolve, and why.
+type PathError struct {
+	Path    string
+	Segment string
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	return "gmap path " + strconv.Quote(e.Path) + " at " + strconv.Quote(e.Segment) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, so errors.Is matches the sentinel errors above.
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// PatchError records which operation of a patch failed, and why.
+type PatchError struct {
+	Index int
+	Op    string
+	Err   error
+}
+
+func (e *PatchError) Error() string {
+	return "gmap patch operation " + strconv.Itoa(e.Index) + " (" + e.Op + "): " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, so errors.Is matches the sentinel errors above.
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// Error describes a value that was missing, nil, or could not be converted to the requested type, and where it was found.
+// It matches the sentinel errors above through errors.Is, e.g. errors.Is(err, ErrTypeMismatch).
+type Error struct {
+	// Key is the key, or the path, of the value.
+	Key string
+	// Type is the type the value was requested as, e.g. "int" or "time.Time".
+	// It is empty if the key was required without a type, as by Reader.Require.
+	Type string
+	// Actual is the Go type of the value, e.g. "string". It is empty if the value is missing or nil.
+	Actual string
+	// Value is the value that could not be converted. It is nil if the value is missing or nil,
+	// or if the error has been redacted.
+	Value interface{}
+	// Err is the underlying error.
+	Err error
+
+	redacted bool
+}
+
+func (e *Error) Error() string {
+	if e.Type == "" {
+		return "gmap key " + strconv.Quote(e.Key) + ": " + e.Err.Error()
+	}
+	if e.Actual == "" {
+		return "gmap key " + strconv.Quote(e.Key) + ": cannot read " + e.Type + ": " + e.Err.Error()
+	}
+
+	msg := "gmap key " + strconv.Quote(e.Key) + ": cannot convert " + e.Actual
+	if !e.redacted {
+		if s, ok := e.Value.(string); ok {
+			msg += " " + strconv.Quote(s)
+		} else {
+			msg += " " + fmt.Sprint(e.Value)
+		}
+	}
+	return msg + " to " + e.Type + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error, so errors.Is matches the sentinel errors above.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wraps an error converting value, found at key, to the type named target.
+// Returns nil if err is nil.
+func newValueError(key, target string, value interface{}, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Key: key, Type: target, Actual: fmt.Sprintf("%T", value), Value: value, Err: err}
+}
+
+// Formats the errors of a *DecodeError, *FormError or *ReaderError after prefix, separated by semicolons.
+func formatErrors(prefix string, errs []error) string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return prefix + ": " + strings.Join(msgs, "; ")
+}
+
+// Returns a copy of errs with each error redacted.
+func redactErrors(errs []error) []error {
+	redacted := make([]error, len(errs))
+	for i, err := range errs {
+		redacted[i] = Redact(err)
+	}
+	return redacted
+}
+
+// Wraps ErrKeyDoesNotExist or ErrNilValue for key, which was requested as the type named target, if any.
+func newKeyError(key, target string, err error) error {
+	return &Error{Key: key, Type: target, Err: err}
+}
+
+// Redact removes the values from an *Error, and from the *Error values inside a *PathError, *DecodeError,
+// *FormError or *ReaderError, so that the error can be logged or returned to a client without leaking them.
+// Other errors are returned as they are.
+func Redact(err error) error {
+	switch err.(type) {
+	case *Error:
+		e := *err.(*Error)
+		e.Value = nil
+		e.redacted = true
+		// errors that repeat the value are replaced with the errors they wrap
+		switch e.Err.(type) {
+		case *strconv.NumError:
+			e.Err = e.Err.(*strconv.NumError).Err
+		case *TimeParseError:
+			e.Err = ErrInvalidTime
+		}
+		return &e
+
+	case *PathError:
+		e := *err.(*PathError)
+		e.Err = Redact(e.Err)
+		return &e
+
+	case *DecodeError:
+		return &DecodeError{Errors: redactErrors(err.(*DecodeError).Errors)}
+
+	case *FormError:
+		return &FormError{Errors: redactErrors(err.(*FormError).Errors)}
+
+	case *ReaderError:
+		return &ReaderError{Errors: redactErrors(err.(*ReaderError).Errors)}
+
+	default:
+		return err
+	}
+}
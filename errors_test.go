@@ -0,0 +1,91 @@
+package gmap
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"age": "abc", "user": Map{"age": Map{}}, "when": "soon", "name": "John"}
+
+	_, err = gmap.Int("age", 0)
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	e, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, "age", e.Key)
+	assert.Equal(t, "int", e.Type)
+	assert.Equal(t, "string", e.Actual)
+	assert.Equal(t, "abc", e.Value)
+	assert.Equal(t, `gmap key "age": cannot convert string "abc" to int: strconv.Atoi: parsing "abc": invalid syntax`, err.Error())
+
+	_, err = gmap.IntAt("user.age", 0)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, "user.age", err.(*Error).Key)
+	assert.Equal(t, "gmap.Map", err.(*Error).Actual)
+	assert.Equal(t, `gmap key "user.age": cannot convert gmap.Map map[] to int: gmap value type mismatch`, err.Error())
+
+	_, err = gmap.Time("when", time.Time{})
+	assert.True(t, errors.Is(err, ErrInvalidTime))
+	assert.Equal(t, "time.Time", err.(*Error).Type)
+
+	_, err = Get[int8](gmap, "name", 0)
+	assert.Equal(t, "int8", err.(*Error).Type)
+
+	// missing and nil values name the key too
+	_, err = gmap.Int("missing", 0)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.Equal(t, "missing", err.(*Error).Key)
+	assert.Equal(t, "int", err.(*Error).Type)
+	assert.Nil(t, err.(*Error).Value)
+	assert.Equal(t, `gmap key "missing": cannot read int: gmap key does not exist`, err.Error())
+
+	gmap["nothing"] = nil
+	_, err = gmap.Time("nothing", time.Time{})
+	assert.True(t, errors.Is(err, ErrNilValue))
+	assert.Equal(t, `gmap key "nothing": cannot read time.Time: gmap value is nil`, err.Error())
+}
+
+func TestRedact(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"password": "hunter2", "token": "secret-token", "user": Map{"pin": "1234x"}}
+
+	_, err = gmap.Int("password", 0)
+	assert.Contains(t, err.Error(), "hunter2")
+	err = Redact(err)
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	assert.Nil(t, err.(*Error).Value)
+	assert.Equal(t, `gmap key "password": cannot convert string to int: invalid syntax`, err.Error())
+
+	_, err = gmap.Time("token", time.Time{})
+	err = Redact(err)
+	assert.True(t, errors.Is(err, ErrInvalidTime))
+	assert.NotContains(t, err.Error(), "secret-token")
+
+	_, err = gmap.FloatAt("user.pin", 0)
+	err = Redact(err)
+	assert.NotContains(t, err.Error(), "1234x")
+	assert.Equal(t, "user.pin", err.(*Error).Key)
+
+	var dst struct {
+		Password int `gmap:"password"`
+		Token    int `gmap:"token"`
+	}
+	err = gmap.Decode(&dst)
+	assert.NotNil(t, err)
+	err = Redact(err)
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.NotContains(t, err.Error(), "secret-token")
+	assert.True(t, errors.Is(err.(*DecodeError).Errors[0], strconv.ErrSyntax))
+
+	assert.Equal(t, ErrNilValue, Redact(ErrNilValue))
+	assert.Nil(t, Redact(nil))
+}
@@ -0,0 +1,142 @@
+package gmap
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IndexStyle determines how array indices are written in flattened keys.
+type IndexStyle int
+
+const (
+	// IndexAsKey writes indices like any other key, e.g. 'list.0.name', or 'list[0][name]' with brackets.
+	IndexAsKey IndexStyle = iota
+	// IndexBracketed always writes indices in brackets, e.g. 'list[0].name'.
+	IndexBracketed
+)
+
+// FlattenOptions configures how nested keys are joined by FlattenWithOptions and split by UnflattenWithOptions.
+type FlattenOptions struct {
+	// Separator joins nested keys, e.g. '.' gives 'a.b.c'. It is not used when Brackets is true.
+	Separator string
+	// Brackets writes nested keys the way FromUrlValues reads them, e.g. 'a[b][c]'.
+	Brackets bool
+	// Indices determines how array indices are written.
+	Indices IndexStyle
+}
+
+// Joins a nested key to the flattened key of its parent.
+func (o FlattenOptions) join(prefix, key string, index bool) string {
+	if prefix == "" {
+		return key
+	}
+	if o.Brackets || (index && o.Indices == IndexBracketed) {
+		return prefix + "[" + key + "]"
+	}
+	return prefix + o.Separator + key
+}
+
+// Splits a flattened key into its nested keys.
+func (o FlattenOptions) split(key string) []string {
+	parts := []string{key}
+	if !o.Brackets && o.Separator != "" {
+		parts = strings.Split(key, o.Separator)
+	}
+
+	if !o.Brackets && o.Indices != IndexBracketed {
+		return parts
+	}
+
+	segments := make([]string, 0, len(parts))
+	for _, part := range parts {
+		segments = append(segments, strings.FieldsFunc(part, func(c rune) bool {
+			return c == '[' || c == ']'
+		})...)
+	}
+	return segments
+}
+
+// Adds the leaves of value, found at prefix, to flat.
+// Empty Maps and arrays are kept as they are, so that they survive Unflatten.
+func (o FlattenOptions) flatten(prefix string, value interface{}, flat Map) {
+	if arr, err := interfaceToArray(value, nil); err == nil && len(arr) > 0 {
+		for i, v := range arr {
+			o.flatten(o.join(prefix, strconv.Itoa(i), true), v, flat)
+		}
+		return
+	}
+
+	if mp, err := interfaceToMap(value, nil); err == nil && len(mp) > 0 {
+		for k, v := range mp {
+			o.flatten(o.join(prefix, k, false), v, flat)
+		}
+		return
+	}
+
+	flat[prefix] = value
+}
+
+// Flattens nested Maps and arrays into a single Map, joining nested keys with sep.
+// For example, {"a": {"b": 1}, "list": [2]} becomes {"a.b": 1, "list.0": 2}.
+// Returns a new Map.
+func (m Map) Flatten(sep string) Map {
+	return m.FlattenWithOptions(FlattenOptions{Separator: sep})
+}
+
+// Flattens nested Maps and arrays into a single Map, joining nested keys as configured by opts.
+// Returns a new Map.
+func (m Map) FlattenWithOptions(opts FlattenOptions) Map {
+	flat := Map{}
+	for k, v := range m {
+		opts.flatten(k, v, flat)
+	}
+	return flat
+}
+
+// Rebuilds nested Maps and arrays from a flattened Map whose keys are joined with sep.
+// Numeric keys create arrays, which are padded with nil values for any missing indices.
+// Returns a *PathError wrapping ErrKeyConflict if keys conflict, e.g. 'a' and 'a.b'.
+func Unflatten(flat Map, sep string) (Map, error) {
+	return UnflattenWithOptions(flat, FlattenOptions{Separator: sep})
+}
+
+// Rebuilds nested Maps and arrays from a flattened Map whose keys are joined as configured by opts.
+// Numeric keys create arrays, which are padded with nil values for any missing indices.
+// Returns a *PathError wrapping ErrKeyConflict if keys conflict, e.g. 'a' and 'a.b',
+// or wrapping ErrIndexOutOfRange if an index would pad an array with more than 10000 nil values, as Set does.
+func UnflattenWithOptions(flat Map, opts FlattenOptions) (Map, error) {
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	mp := Map{}
+	for _, key := range keys {
+		segments := opts.split(key)
+		if len(segments) == 0 {
+			segments = []string{key}
+		}
+
+		value := flat[key]
+		_, err := update(key, mp, segments, true, func(container interface{}, segment string) (interface{}, error) {
+			if existing, err := child(container, segment); err == nil && existing != nil {
+				return nil, ErrKeyConflict
+			}
+			return put(container, segment, value)
+		})
+
+		// a value where a Map or array is needed is also a conflict
+		var pe *PathError
+		if errors.As(err, &pe) && pe.Err == ErrTypeMismatch {
+			pe.Err = ErrKeyConflict
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return mp, nil
+}
@@ -0,0 +1,90 @@
+package gmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testNestedMap() Map {
+	return Map{
+		"name": "John",
+		"address": Map{
+			"zip":  "94105",
+			"city": "San Francisco",
+		},
+		"tags": []interface{}{"a", "b"},
+		"orders": []interface{}{
+			Map{"id": 1, "items": []interface{}{"apple"}},
+		},
+		"empty":   Map{},
+		"none":    []interface{}{},
+		"nothing": nil,
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	flat := testNestedMap().Flatten(".")
+	assert.Equal(t, Map{
+		"name":             "John",
+		"address.zip":      "94105",
+		"address.city":     "San Francisco",
+		"tags.0":           "a",
+		"tags.1":           "b",
+		"orders.0.id":      1,
+		"orders.0.items.0": "apple",
+		"empty":            Map{},
+		"none":             []interface{}{},
+		"nothing":          nil,
+	}, flat)
+
+	flat = testNestedMap().Flatten("_")
+	assert.Equal(t, "apple", flat["orders_0_items_0"])
+}
+
+func TestFlattenWithOptions(t *testing.T) {
+	flat := testNestedMap().FlattenWithOptions(FlattenOptions{Brackets: true})
+	assert.Equal(t, "94105", flat["address[zip]"])
+	assert.Equal(t, "b", flat["tags[1]"])
+	assert.Equal(t, "apple", flat["orders[0][items][0]"])
+
+	flat = testNestedMap().FlattenWithOptions(FlattenOptions{Separator: ".", Indices: IndexBracketed})
+	assert.Equal(t, "94105", flat["address.zip"])
+	assert.Equal(t, "b", flat["tags[1]"])
+	assert.Equal(t, "apple", flat["orders[0].items[0]"])
+}
+
+func TestUnflatten(t *testing.T) {
+	mp, err := Unflatten(testNestedMap().Flatten("."), ".")
+	assert.Nil(t, err)
+	assert.Equal(t, testNestedMap(), mp)
+
+	for _, opts := range []FlattenOptions{
+		{Brackets: true},
+		{Separator: "/", Indices: IndexBracketed},
+		{Separator: "__"},
+	} {
+		mp, err = UnflattenWithOptions(testNestedMap().FlattenWithOptions(opts), opts)
+		assert.Nil(t, err)
+		assert.Equal(t, testNestedMap(), mp)
+	}
+
+	mp, err = Unflatten(Map{"list.2": "c", "list.0": "a"}, ".")
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"list": []interface{}{"a", nil, "c"}}, mp)
+
+	mp, err = Unflatten(Map{"a": 1, "a.b": 2}, ".")
+	assert.Nil(t, mp)
+	assert.True(t, errors.Is(err, ErrKeyConflict))
+	assert.Equal(t, "a.b", err.(*PathError).Path)
+
+	_, err = UnflattenWithOptions(Map{"a[b]": 1, "a.b": 2}, FlattenOptions{Separator: ".", Indices: IndexBracketed})
+	assert.True(t, errors.Is(err, ErrKeyConflict))
+
+	_, err = Unflatten(Map{"list.0": 1, "list.x": 2}, ".")
+	assert.True(t, errors.Is(err, ErrKeyConflict))
+
+	_, err = Unflatten(Map{"a.4611686018427387903": 1}, ".")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+}
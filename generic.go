@@ -0,0 +1,147 @@
+package gmap
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	mapType      = reflect.TypeOf(Map{})
+	arrayType    = reflect.TypeOf([]interface{}{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// Helper function to convert an interface{} to T.
+// Values already of type T are returned as they are. Otherwise the conversion is chosen by the kind of T,
+// so that named types such as `type Status string` convert the same way as their underlying type.
+func interfaceToType[T any](v interface{}, def T) (T, error) {
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+
+	var converted interface{}
+	var err error
+	rt := reflect.TypeOf(&def).Elem()
+	switch {
+	case rt == timeType:
+		converted, err = interfaceToTime(v, time.Time{})
+	case rt == durationType:
+		converted, err = interfaceToDuration(v, 0, time.Second)
+	case rt == mapType:
+		converted, err = interfaceToMap(v, nil)
+	case rt == arrayType:
+		converted, err = interfaceToArray(v, nil)
+	case rt == reflect.PtrTo(bigIntType):
+		converted, err = interfaceToBigInt(v, nil)
+	case rt == reflect.PtrTo(bigFloatType):
+		converted, err = interfaceToBigFloat(v, nil)
+	case rt == decimalType:
+		converted, err = interfaceToDecimal(v, Decimal{})
+	default:
+		switch rt.Kind() {
+		case reflect.Int:
+			// the same as Int, so fractions are truncated
+			converted, err = interfaceToInt(v, 0)
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			var i int64
+			i, err = interfaceToInt64(v, 0)
+			if err == nil && reflect.Zero(rt).OverflowInt(i) {
+				err = ErrOverflow
+			}
+			converted = i
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			var u uint64
+			u, err = interfaceToUint64(v, 0)
+			if err == nil && reflect.Zero(rt).OverflowUint(u) {
+				err = ErrOverflow
+			}
+			converted = u
+		case reflect.Float32, reflect.Float64:
+			var f float64
+			f, err = interfaceToFloat64(v, 0)
+			if err == nil && reflect.Zero(rt).OverflowFloat(f) {
+				err = ErrOverflow
+			}
+			converted = f
+		case reflect.String:
+			converted, err = interfaceToString(v, "")
+		case reflect.Bool:
+			converted, err = interfaceToBool(v, false)
+		default:
+			return def, ErrTypeMismatch
+		}
+	}
+
+	if err != nil {
+		return def, err
+	}
+	return reflect.ValueOf(converted).Convert(rt).Interface().(T), nil
+}
+
+// Helper function to convert an interface{} to []T
+func interfaceToTypeArray[T any](v interface{}, def []T) ([]T, error) {
+	switch v.(type) {
+	case []T:
+		val := v.([]T)
+		ta := make([]T, len(val))
+		copy(ta, val)
+		return ta, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return def, ErrTypeMismatch
+	}
+
+	var zero T
+	ta := make([]T, rv.Len())
+	for i := range ta {
+		var err error
+		ta[i], err = interfaceToType(rv.Index(i).Interface(), zero)
+		if err == ErrOverflow || err == ErrPrecisionLoss {
+			return def, err
+		}
+		if err != nil {
+			return def, ErrElementTypeMismatch
+		}
+	}
+	return ta, nil
+}
+
+// Retrieves a value of type T, converting it the same way as the other getters,
+// e.g. Get[int64](m, "id", 0) or Get[float32](m, "ratio", 0).
+// Get[int] converts the same way as Int, truncating fractions. Other integer types are converted the same way
+// as Int64 and Uint64, returning ErrOverflow or ErrPrecisionLoss instead of truncating, and so are floats
+// that do not fit, e.g. 1e40 for Get[float32].
+// Returns the default value and an error if key does not exist or nil.
+func Get[T any](m Map, key string, def T) (T, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, reflect.TypeOf(&def).Elem().String(), ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, reflect.TypeOf(&def).Elem().String(), ErrNilValue)
+	}
+
+	v, err := interfaceToType(value, def)
+	return v, newValueError(key, reflect.TypeOf(&def).Elem().String(), value, err)
+}
+
+// Retrieves an array of T, converting each element the same way as Get,
+// e.g. GetArray[bool](m, "flags", nil) or GetArray[time.Time](m, "dates", nil).
+// Elements that overflow T or lose precision return ErrOverflow or ErrPrecisionLoss rather than ErrElementTypeMismatch.
+// Returns the default value and an error if key does not exist or nil.
+func GetArray[T any](m Map, key string, def []T) ([]T, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, reflect.TypeOf(&def).Elem().String(), ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, reflect.TypeOf(&def).Elem().String(), ErrNilValue)
+	}
+
+	v, err := interfaceToTypeArray(value, def)
+	return v, newValueError(key, reflect.TypeOf(&def).Elem().String(), value, err)
+}
@@ -0,0 +1,141 @@
+package gmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatus string
+
+func TestGet(t *testing.T) {
+	var gmap Map
+
+	created := time.Date(2017, time.July, 10, 12, 13, 47, 0, time.UTC)
+	gmap = Map{
+		"id":      "42",
+		"ratio":   0.5,
+		"count":   uint32(7),
+		"flag":    "true",
+		"status":  "active",
+		"created": "2017-07-10T12:13:47Z",
+		"user":    map[string]interface{}{"name": "John"},
+		"nothing": nil,
+		"bad":     "abc",
+		"list":    []interface{}{1},
+		"large":   300,
+		"huge":    1e40,
+	}
+
+	id, err := Get[int64](gmap, "id", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), id)
+
+	ratio, err := Get[float32](gmap, "ratio", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, float32(0.5), ratio)
+
+	count, err := Get[uint32](gmap, "count", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(7), count)
+
+	flag, err := Get[bool](gmap, "flag", false)
+	assert.Nil(t, err)
+	assert.True(t, flag)
+
+	status, err := Get[testStatus](gmap, "status", "")
+	assert.Nil(t, err)
+	assert.Equal(t, testStatus("active"), status)
+
+	tm, err := Get[time.Time](gmap, "created", time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, created, tm)
+
+	user, err := Get[Map](gmap, "user", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"name": "John"}, user)
+
+	value, err := Get[interface{}](gmap, "list", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, value)
+
+	id, err = Get[int64](gmap, "missing", 10)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.Equal(t, int64(10), id)
+
+	id, err = Get[int64](gmap, "nothing", 10)
+	assert.True(t, errors.Is(err, ErrNilValue))
+	assert.Equal(t, int64(10), id)
+
+	id, err = Get[int64](gmap, "bad", 10)
+	assert.NotNil(t, err)
+	assert.Equal(t, int64(10), id)
+
+	_, err = Get[int8](gmap, "large", 0)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	// Get[int] truncates the same way as Int, Get[int64] does not
+	n, err := Get[int](gmap, "ratio", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, n)
+
+	_, err = Get[int64](gmap, "ratio", 0)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	_, err = Get[float32](gmap, "huge", 0)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = Get[complex64](gmap, "id", 0)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	_, err = Get[Map](gmap, "id", nil)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+}
+
+func TestGetArray(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"flags":   []interface{}{true, "false"},
+		"ids":     []string{"1", "2"},
+		"ratios":  []float32{0.5, 1.5},
+		"dates":   []interface{}{"2017-07-10T12:13:47Z"},
+		"mixed":   []interface{}{1, "two"},
+		"scalar":  1,
+		"nothing": nil,
+	}
+
+	flags, err := GetArray[bool](gmap, "flags", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, false}, flags)
+
+	ids, err := GetArray[int64](gmap, "ids", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 2}, ids)
+
+	ratios, err := GetArray[float32](gmap, "ratios", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []float32{0.5, 1.5}, ratios)
+	ratios[0] = 10
+	assert.Equal(t, []float32{0.5, 1.5}, gmap["ratios"])
+
+	dates, err := GetArray[time.Time](gmap, "dates", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Time{time.Date(2017, time.July, 10, 12, 13, 47, 0, time.UTC)}, dates)
+
+	def := []int{9}
+	mixed, err := GetArray[int](gmap, "mixed", def)
+	assert.True(t, errors.Is(err, ErrElementTypeMismatch))
+	assert.Equal(t, def, mixed)
+
+	_, err = GetArray[int](gmap, "scalar", nil)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	_, err = GetArray[int](gmap, "missing", nil)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	_, err = GetArray[int](gmap, "nothing", nil)
+	assert.True(t, errors.Is(err, ErrNilValue))
+}
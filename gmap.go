@@ -1,20 +1,22 @@
 package gmap
 
 import (
+	"math/big"
 	"net/url"
-	"strconv"
-	"strings"
 	"time"
 )
 
-var timeformats = []string{
-	"2006-01-02T15:04:05Z",
-	"Mon, 02 Jan 2006 15:04:05 MST",
-	"02/Jan/2006:15:04:05 -0700",
-	"2006-01-02 15:04:05 -0700",
-	"2006-01-02 15:04:05 MST",
-	"2006-01-02 15:04:05 -0700 MST",
-}
+// Time layouts recognized when converting a string to time.Time.
+const (
+	TimeFormatISO8601     = "2006-01-02T15:04:05Z"
+	TimeFormatRFC3339Nano = time.RFC3339Nano
+	TimeFormatRFC1123     = "Mon, 02 Jan 2006 15:04:05 MST"
+	TimeFormatCommonLog   = "02/Jan/2006:15:04:05 -0700"
+	TimeFormatRubyOffset  = "2006-01-02 15:04:05 -0700"
+	TimeFormatRuby        = "2006-01-02 15:04:05 MST"
+	TimeFormatGo          = "2006-01-02 15:04:05 -0700 MST"
+	TimeFormatDate        = "2006-01-02"
+)
 
 // Map provides various utility functions for map[string]interface{}.
 type Map map[string]interface{}
@@ -24,32 +26,15 @@ type Map map[string]interface{}
 func (m Map) Map(key string, def Map) (Map, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "gmap.Map", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
-	}
-
-	switch value.(type) {
-	case map[string]interface{}:
-		return Map(value.(map[string]interface{})), nil
-	case map[interface{}]interface{}:
-		mp := Map{}
-		mi := value.(map[interface{}]interface{})
-		for k, v := range mi {
-			ks, err := interfaceToString(k, "")
-			if err != nil {
-				return def, ErrTypeMismatch
-			}
-			mp[ks] = v
-		}
-		return mp, nil
-	case Map:
-		return value.(Map), nil
-	default:
-		return def, ErrTypeMismatch
+		return def, newKeyError(key, "gmap.Map", ErrNilValue)
 	}
+
+	v, err := interfaceToMap(value, def)
+	return v, newValueError(key, "gmap.Map", value, err)
 }
 
 // Retrieves an array of interface{}.
@@ -57,19 +42,15 @@ func (m Map) Map(key string, def Map) (Map, error) {
 func (m Map) Array(key string, def []interface{}) ([]interface{}, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "[]interface {}", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
+		return def, newKeyError(key, "[]interface {}", ErrNilValue)
 	}
 
-	switch value.(type) {
-	case []interface{}:
-		return value.([]interface{}), nil
-	default:
-		return def, ErrTypeMismatch
-	}
+	v, err := interfaceToArray(value, def)
+	return v, newValueError(key, "[]interface {}", value, err)
 }
 
 // Retrieves an int.
@@ -77,14 +58,110 @@ func (m Map) Array(key string, def []interface{}) ([]interface{}, error) {
 func (m Map) Int(key string, def int) (int, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "int", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "int", ErrNilValue)
+	}
+
+	v, err := interfaceToInt(value, def)
+	return v, newValueError(key, "int", value, err)
+}
+
+// IntOptions configures how IntWithOptions converts a value to int.
+type IntOptions struct {
+	// Exact rejects floats with a fractional part, such as 2.9, with ErrPrecisionLoss instead of truncating them,
+	// and values that do not fit in an int with ErrOverflow instead of wrapping them.
+	Exact bool
+}
+
+// Retrieves an int the same way as Int, as configured by opts.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) IntWithOptions(key string, def int, opts IntOptions) (int, error) {
+	if !opts.Exact {
+		return m.Int(key, def)
+	}
+
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "int", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "int", ErrNilValue)
+	}
+
+	v, err := interfaceToExactInt(value, def)
+	return v, newValueError(key, "int", value, err)
+}
+
+// Retrieves an int64.
+// Returns ErrOverflow if the value does not fit, or ErrPrecisionLoss if it has a fractional part.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Int64(key string, def int64) (int64, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "int64", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "int64", ErrNilValue)
+	}
+
+	v, err := interfaceToInt64(value, def)
+	return v, newValueError(key, "int64", value, err)
+}
+
+// Retrieves a uint64.
+// Returns ErrOverflow if the value is negative or does not fit, or ErrPrecisionLoss if it has a fractional part.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Uint64(key string, def uint64) (uint64, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "uint64", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "uint64", ErrNilValue)
+	}
+
+	v, err := interfaceToUint64(value, def)
+	return v, newValueError(key, "uint64", value, err)
+}
+
+// Retrieves an int32.
+// Returns ErrOverflow if the value does not fit, or ErrPrecisionLoss if it has a fractional part.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Int32(key string, def int32) (int32, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "int32", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "int32", ErrNilValue)
+	}
+
+	v, err := interfaceToInt32(value, def)
+	return v, newValueError(key, "int32", value, err)
+}
+
+// Retrieves a uint32.
+// Returns ErrOverflow if the value is negative or does not fit, or ErrPrecisionLoss if it has a fractional part.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Uint32(key string, def uint32) (uint32, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "uint32", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
+		return def, newKeyError(key, "uint32", ErrNilValue)
 	}
 
-	return interfaceToInt(value, def)
+	v, err := interfaceToUint32(value, def)
+	return v, newValueError(key, "uint32", value, err)
 }
 
 // Retrieves a float.
@@ -92,14 +169,77 @@ func (m Map) Int(key string, def int) (int, error) {
 func (m Map) Float(key string, def float64) (float64, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "float64", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
+		return def, newKeyError(key, "float64", ErrNilValue)
 	}
 
-	return interfaceToFloat64(value, def)
+	v, err := interfaceToFloat64(value, def)
+	return v, newValueError(key, "float64", value, err)
+}
+
+// Retrieves a *big.Int, so that integers of any size can be read without losing precision.
+// Always returns a new *big.Int, which can be modified without changing the map.
+// Returns ErrPrecisionLoss if the value has a fractional part.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) BigInt(key string, def *big.Int) (*big.Int, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "*big.Int", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "*big.Int", ErrNilValue)
+	}
+
+	v, err := interfaceToBigInt(value, def)
+	return v, newValueError(key, "*big.Int", value, err)
+}
+
+// Retrieves a *big.Float, so that high-precision numbers such as json.Number can be read without rounding.
+// Always returns a new *big.Float, which can be modified without changing the map.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) BigFloat(key string, def *big.Float) (*big.Float, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "*big.Float", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "*big.Float", ErrNilValue)
+	}
+
+	v, err := interfaceToBigFloat(value, def)
+	return v, newValueError(key, "*big.Float", value, err)
+}
+
+// Retrieves a Decimal, such as a price or a balance, without the rounding errors of float64.
+// Strings, json.Number and floats are read from their decimal digits, so "19.99" and 19.99 are both 19.99.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Decimal(key string, def Decimal) (Decimal, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "gmap.Decimal", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "gmap.Decimal", ErrNilValue)
+	}
+
+	v, err := interfaceToDecimal(value, def)
+	return v, newValueError(key, "gmap.Decimal", value, err)
+}
+
+// Retrieves a Decimal the same way as Decimal, rounded to opts.Scale digits after the decimal point.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) DecimalWithOptions(key string, def Decimal, opts DecimalOptions) (Decimal, error) {
+	d, err := m.Decimal(key, def)
+	if err != nil {
+		return d, err
+	}
+	return d.Round(opts.Scale, opts.Rounding), nil
 }
 
 // Retrieves a string.
@@ -107,14 +247,15 @@ func (m Map) Float(key string, def float64) (float64, error) {
 func (m Map) String(key string, def string) (string, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "string", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
+		return def, newKeyError(key, "string", ErrNilValue)
 	}
 
-	return interfaceToString(value, def)
+	v, err := interfaceToString(value, def)
+	return v, newValueError(key, "string", value, err)
 }
 
 // Retrieves a boolean.
@@ -122,21 +263,15 @@ func (m Map) String(key string, def string) (string, error) {
 func (m Map) Boolean(key string, def bool) (bool, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "bool", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
+		return def, newKeyError(key, "bool", ErrNilValue)
 	}
 
-	switch value.(type) {
-	case bool:
-		return value.(bool), nil
-	case string:
-		return strconv.ParseBool(value.(string))
-	default:
-		return def, ErrTypeMismatch
-	}
+	v, err := interfaceToBool(value, def)
+	return v, newValueError(key, "bool", value, err)
 }
 
 // Retrieves a string array.
@@ -144,36 +279,15 @@ func (m Map) Boolean(key string, def bool) (bool, error) {
 func (m Map) StringArray(key string, def []string) ([]string, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "[]string", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
-	}
-
-	var err error
-	var sa []string
-	switch value.(type) {
-	case []interface{}:
-		val := value.([]interface{})
-		sa = make([]string, len(val))
-		for i, v := range val {
-			sa[i], err = interfaceToString(v, "")
-			if err != nil {
-				return def, ErrElementTypeMismatch
-			}
-		}
-		return sa, nil
-
-	case []string:
-		val := value.([]string)
-		sa = make([]string, len(val))
-		copy(sa, val)
-		return sa, nil
-
-	default:
-		return def, ErrTypeMismatch
+		return def, newKeyError(key, "[]string", ErrNilValue)
 	}
+
+	v, err := interfaceToStringArray(value, def)
+	return v, newValueError(key, "[]string", value, err)
 }
 
 // Retrieves an float64 array.
@@ -181,36 +295,15 @@ func (m Map) StringArray(key string, def []string) ([]string, error) {
 func (m Map) FloatArray(key string, def []float64) ([]float64, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "[]float64", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
-	}
-
-	var err error
-	var fa []float64
-	switch value.(type) {
-	case []interface{}:
-		val := value.([]interface{})
-		fa = make([]float64, len(val))
-		for i, v := range val {
-			fa[i], err = interfaceToFloat64(v, 0.0)
-			if err != nil {
-				return def, ErrElementTypeMismatch
-			}
-		}
-		return fa, nil
-
-	case []float64:
-		val := value.([]float64)
-		fa = make([]float64, len(val))
-		copy(fa, val)
-		return fa, nil
-
-	default:
-		return def, ErrTypeMismatch
+		return def, newKeyError(key, "[]float64", ErrNilValue)
 	}
+
+	v, err := interfaceToFloat64Array(value, def)
+	return v, newValueError(key, "[]float64", value, err)
 }
 
 // Retrieves an int array.
@@ -218,70 +311,107 @@ func (m Map) FloatArray(key string, def []float64) ([]float64, error) {
 func (m Map) IntArray(key string, def []int) ([]int, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "[]int", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
-	}
-
-	var err error
-	var ia []int
-	switch value.(type) {
-	case []interface{}:
-		val := value.([]interface{})
-		ia = make([]int, len(val))
-		for i, v := range val {
-			ia[i], err = interfaceToInt(v, 0)
-			if err != nil {
-				return def, ErrElementTypeMismatch
-			}
-		}
-		return ia, nil
+		return def, newKeyError(key, "[]int", ErrNilValue)
+	}
+
+	v, err := interfaceToIntArray(value, def)
+	return v, newValueError(key, "[]int", value, err)
+}
 
-	case []int:
-		val := value.([]int)
-		ia = make([]int, len(val))
-		copy(ia, val)
-		return ia, nil
+// Retrieves an int64 array.
+// Returns ErrOverflow or ErrPrecisionLoss if any element cannot be converted exactly.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Int64Array(key string, def []int64) ([]int64, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "[]int64", ErrKeyDoesNotExist)
+	}
 
-	default:
-		return def, ErrTypeMismatch
+	if value == nil {
+		return def, newKeyError(key, "[]int64", ErrNilValue)
 	}
+
+	v, err := interfaceToTypeArray(value, def)
+	return v, newValueError(key, "[]int64", value, err)
 }
 
-// Retrieves time.
-// Can convert time value if it's a string and in the recognized format.
+// Retrieves a uint64 array.
+// Returns ErrOverflow or ErrPrecisionLoss if any element cannot be converted exactly.
 // Returns the default value and an error if key does not exist or nil.
-func (m Map) Time(key string, def time.Time) (time.Time, error) {
+func (m Map) Uint64Array(key string, def []uint64) ([]uint64, error) {
 	value, ok := m[key]
 	if !ok {
-		return def, ErrKeyDoesNotExist
+		return def, newKeyError(key, "[]uint64", ErrKeyDoesNotExist)
 	}
 
 	if value == nil {
-		return def, ErrNilValue
-	}
-
-	switch value.(type) {
-	case time.Time:
-		val := value.(time.Time)
-		return val, nil
-
-	case string:
-		var t time.Time
-		var err error
-		for _, tf := range timeformats {
-			t, err = time.Parse(tf, value.(string))
-			if err == nil {
-				return t, nil
-			}
-		}
-		return t, err
+		return def, newKeyError(key, "[]uint64", ErrNilValue)
+	}
 
-	default:
-		return def, ErrTypeMismatch
+	v, err := interfaceToTypeArray(value, def)
+	return v, newValueError(key, "[]uint64", value, err)
+}
+
+// Retrieves an int32 array.
+// Returns ErrOverflow or ErrPrecisionLoss if any element cannot be converted exactly.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Int32Array(key string, def []int32) ([]int32, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "[]int32", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "[]int32", ErrNilValue)
 	}
+
+	v, err := interfaceToTypeArray(value, def)
+	return v, newValueError(key, "[]int32", value, err)
+}
+
+// Retrieves a uint32 array.
+// Returns ErrOverflow or ErrPrecisionLoss if any element cannot be converted exactly.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Uint32Array(key string, def []uint32) ([]uint32, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "[]uint32", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "[]uint32", ErrNilValue)
+	}
+
+	v, err := interfaceToTypeArray(value, def)
+	return v, newValueError(key, "[]uint32", value, err)
+}
+
+// Retrieves time.
+// Can convert time value if it's a string and in the recognized format, or a Unix epoch number if DefaultTimeParser.Epoch is set.
+// Returns the default value and an error if key does not exist or nil, or a *TimeParseError if the string is not recognized.
+func (m Map) Time(key string, def time.Time) (time.Time, error) {
+	return m.TimeWithParser(key, def, DefaultTimeParser)
+}
+
+// Retrieves time the same way as Time, but converts it with the given parser,
+// e.g. to read epoch milliseconds or a custom layout. A nil parser is the same as DefaultTimeParser.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) TimeWithParser(key string, def time.Time, parser *TimeParser) (time.Time, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "time.Time", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "time.Time", ErrNilValue)
+	}
+
+	v, err := interfaceToTimeWithParser(value, def, parser)
+	return v, newValueError(key, "time.Time", value, err)
 }
 
 // Retrieves time, but also converts to UTC.
@@ -292,6 +422,66 @@ func (m Map) TimeUTC(key string, def time.Time) (time.Time, error) {
 	return t.UTC(), err
 }
 
+// DurationOptions configures how DurationWithOptions converts a value to time.Duration.
+type DurationOptions struct {
+	// Unit is the unit of plain numbers, such as 1500 or 2.5. Defaults to time.Second.
+	Unit time.Duration
+}
+
+// Returns the unit of plain numbers.
+func (o DurationOptions) unit() time.Duration {
+	if o.Unit == 0 {
+		return time.Second
+	}
+	return o.Unit
+}
+
+// Retrieves a time.Duration.
+// Can convert strings in time.ParseDuration format, such as "1m30s", or ISO 8601 format, such as "PT1M30S".
+// Plain numbers, such as 30 or "2.5", are in seconds.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) Duration(key string, def time.Duration) (time.Duration, error) {
+	return m.DurationWithOptions(key, def, DurationOptions{})
+}
+
+// Retrieves a time.Duration the same way as Duration, as configured by opts.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) DurationWithOptions(key string, def time.Duration, opts DurationOptions) (time.Duration, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "time.Duration", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "time.Duration", ErrNilValue)
+	}
+
+	v, err := interfaceToDuration(value, def, opts.unit())
+	return v, newValueError(key, "time.Duration", value, err)
+}
+
+// Retrieves a time.Duration array, converting each element the same way as Duration.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) DurationArray(key string, def []time.Duration) ([]time.Duration, error) {
+	return m.DurationArrayWithOptions(key, def, DurationOptions{})
+}
+
+// Retrieves a time.Duration array, converting each element the same way as DurationWithOptions.
+// Returns the default value and an error if key does not exist or nil.
+func (m Map) DurationArrayWithOptions(key string, def []time.Duration, opts DurationOptions) ([]time.Duration, error) {
+	value, ok := m[key]
+	if !ok {
+		return def, newKeyError(key, "[]time.Duration", ErrKeyDoesNotExist)
+	}
+
+	if value == nil {
+		return def, newKeyError(key, "[]time.Duration", ErrNilValue)
+	}
+
+	v, err := interfaceToDurationArray(value, def, opts.unit())
+	return v, newValueError(key, "[]time.Duration", value, err)
+}
+
 // Slice returns a new Map with only the given keys.
 // Opposite of Except.
 func (m Map) Slice(keys ...string) Map {
@@ -322,51 +512,11 @@ func (m Map) Except(keys ...string) Map {
 
 // Fills map with values from url.Values.
 // Recognizes keys that are in hash form such as 'foo[bar]' and creates a nested map.
+// Numeric keys such as 'items[0][name]' and empty brackets such as 'tags[]' create arrays,
+// unless the same nested map also has other keys, such as 'foo[0]' and 'foo[bar]'.
 // Single-element string arrays will be unpacked to regular strings.
 func (m Map) FromUrlValues(values url.Values) {
-	for k, v := range values {
-		subkeys := strings.FieldsFunc(k, func(c rune) bool {
-			return c == '[' || c == ']'
-		})
-
-		// normal single keys
-		if len(subkeys) == 1 {
-			m[subkeys[0]] = v
-			if len(v) == 1 {
-				m[subkeys[0]] = v[0]
-			}
-			continue
-		}
-
-		// for multiple keys, make sure there are nested maps
-		submap := m
-		lastIndex := len(subkeys) - 1
-		var subkey string
-		for i := 0; i < lastIndex; i++ {
-			subkey = subkeys[i]
-			var mp Map
-			if submap[subkey] == nil {
-				mp = Map{}
-				submap[subkey] = mp
-			} else {
-				// if there already exists a key but has a different type than a map
-				// then we overwrite that value and replace it with a Map
-				switch submap[subkey].(type) {
-				case Map:
-					mp = submap[subkey].(Map)
-				default:
-					mp = Map{}
-					submap[subkey] = mp
-				}
-			}
-			submap = mp
-		}
-
-		submap[subkeys[lastIndex]] = v
-		if len(v) == 1 {
-			submap[subkeys[lastIndex]] = v[0]
-		}
-	}
+	m.FromUrlValuesWithOptions(values, FromUrlValuesOptions{})
 }
 
 // Fills map given an array of keys and values.
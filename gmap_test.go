@@ -2,8 +2,12 @@ package gmap
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,7 +48,7 @@ const testPayload = `
 `
 
 func init() {
-	fmt.Sprintln() // just so we can use fmt
+	_ = fmt.Sprintln() // just so we can use fmt
 }
 
 func TestString(t *testing.T) {
@@ -61,11 +65,11 @@ func TestString(t *testing.T) {
 	assert.EqualValues(t, value, "John")
 
 	value, err = gmap.String("DoesNotExist", "")
-	assert.Equal(t, ErrKeyDoesNotExist, err)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
 	assert.EqualValues(t, "", value)
 
 	value, err = gmap.String("NullValue", "")
-	assert.Equal(t, ErrNilValue, err)
+	assert.True(t, errors.Is(err, ErrNilValue))
 	assert.EqualValues(t, "", value)
 }
 
@@ -149,6 +153,174 @@ func TestInt(t *testing.T) {
 	assert.EqualValues(t, 9, value)
 }
 
+func TestIntWithOptions(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{"whole": 2.0, "fraction": 2.9}
+
+	value, err := gmap.IntWithOptions("fraction", 0, IntOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+
+	value, err = gmap.IntWithOptions("fraction", 9, IntOptions{Exact: true})
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+	assert.Equal(t, 9, value)
+
+	value, err = gmap.IntWithOptions("whole", 0, IntOptions{Exact: true})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+
+	_, err = gmap.IntWithOptions("missing", 0, IntOptions{Exact: true})
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+}
+
+func TestSizedInts(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"id":       "9007199254740993",
+		"negative": -5,
+		"big":      uint64(math.MaxUint64),
+		"fraction": 2.5,
+		"nothing":  nil,
+	}
+
+	i64, err := gmap.Int64("id", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), i64)
+
+	_, err = gmap.Int64("big", 0)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = gmap.Int64("fraction", 0)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	u64, err := gmap.Uint64("big", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u64)
+
+	u64, err = gmap.Uint64("negative", 7)
+	assert.True(t, errors.Is(err, ErrOverflow))
+	assert.Equal(t, uint64(7), u64)
+
+	i32, err := gmap.Int32("negative", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(-5), i32)
+
+	_, err = gmap.Int32("id", 0)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = gmap.Uint32("negative", 0)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = gmap.Uint32("nothing", 0)
+	assert.True(t, errors.Is(err, ErrNilValue))
+
+	_, err = gmap.Int64("missing", 0)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+}
+
+func TestSizedIntArrays(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"ids":      []interface{}{1, "2", 3.0},
+		"negative": []interface{}{1, -1},
+		"fraction": []interface{}{1.5},
+		"mixed":    []interface{}{1, "two"},
+	}
+
+	i64s, err := gmap.Int64Array("ids", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, i64s)
+
+	u32s, err := gmap.Uint32Array("ids", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint32{1, 2, 3}, u32s)
+
+	_, err = gmap.Uint64Array("negative", nil)
+	assert.True(t, errors.Is(err, ErrOverflow))
+
+	_, err = gmap.Int32Array("fraction", nil)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	_, err = gmap.Int64Array("mixed", nil)
+	assert.True(t, errors.Is(err, ErrElementTypeMismatch))
+}
+
+func TestBigNumbers(t *testing.T) {
+	var gmap Map
+
+	decoder := json.NewDecoder(strings.NewReader(`{"id": 9007199254740993, "amount": 12345678901234567890.99, "ratio": 0.5}`))
+	decoder.UseNumber()
+	gmap = Map{}
+	err := decoder.Decode(&gmap)
+	assert.Nil(t, err)
+
+	id, err := gmap.Int64("id", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+
+	s, err := gmap.String("id", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "9007199254740993", s)
+
+	ratio, err := gmap.Float("ratio", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, ratio)
+
+	b, err := gmap.BigInt("id", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "9007199254740993", b.String())
+
+	_, err = gmap.BigInt("amount", nil)
+	assert.True(t, errors.Is(err, ErrPrecisionLoss))
+
+	amount, err := gmap.BigFloat("amount", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234567890.99", amount.Text('f', 2))
+
+	_, err = gmap.BigFloat("missing", nil)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	gen, err := Get[*big.Int](gmap, "id", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, b, gen)
+}
+
+func TestDecimal(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{"price": "19.99", "rate": 0.1, "amount": 2.675, "bad": "abc", "nothing": nil}
+
+	price, err := gmap.Decimal("price", Decimal{})
+	assert.Nil(t, err)
+	assert.Equal(t, "19.99", price.String())
+
+	rate, err := gmap.Decimal("rate", Decimal{})
+	assert.Nil(t, err)
+	assert.Equal(t, "0.1", rate.String())
+
+	amount, err := gmap.DecimalWithOptions("amount", Decimal{}, DecimalOptions{Scale: 2, Rounding: RoundHalfUp})
+	assert.Nil(t, err)
+	assert.Equal(t, "2.68", amount.String())
+
+	amount, err = gmap.DecimalWithOptions("price", Decimal{}, DecimalOptions{Scale: 4})
+	assert.Nil(t, err)
+	assert.Equal(t, "19.9900", amount.String())
+
+	def := NewDecimal(1, 0)
+	price, err = gmap.Decimal("bad", def)
+	assert.NotNil(t, err)
+	assert.Equal(t, def, price)
+
+	_, err = gmap.DecimalWithOptions("nothing", def, DecimalOptions{})
+	assert.True(t, errors.Is(err, ErrNilValue))
+
+	_, err = gmap.Decimal("missing", def)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+}
+
 func TestFloat(t *testing.T) {
 	var gmap Map
 	var err error
@@ -374,6 +546,62 @@ func TestTimeUTC(t *testing.T) {
 	assert.Equal(t, "UTC", zone)
 }
 
+func TestDuration(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"timeout":  "30s",
+		"interval": "PT5M",
+		"delay":    1500,
+		"ratio":    2.5,
+		"bad":      "soon",
+		"nothing":  nil,
+		"list":     []interface{}{"1m", 2, "PT1S"},
+		"mixed":    []interface{}{"1m", "soon"},
+	}
+
+	d, err := gmap.Duration("timeout", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, d)
+
+	d, err = gmap.Duration("interval", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Minute, d)
+
+	d, err = gmap.Duration("ratio", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2500*time.Millisecond, d)
+
+	d, err = gmap.DurationWithOptions("delay", 0, DurationOptions{Unit: time.Millisecond})
+	assert.Nil(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	d, err = gmap.Duration("bad", time.Minute)
+	assert.True(t, errors.Is(err, ErrInvalidDuration))
+	assert.Equal(t, time.Minute, d)
+
+	_, err = gmap.Duration("nothing", 0)
+	assert.True(t, errors.Is(err, ErrNilValue))
+
+	_, err = gmap.Duration("missing", 0)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	ds, err := gmap.DurationArray("list", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Second, time.Second}, ds)
+
+	ds, err = gmap.DurationArrayWithOptions("list", nil, DurationOptions{Unit: time.Minute})
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Minute, ds[1])
+
+	_, err = gmap.DurationArray("mixed", nil)
+	assert.True(t, errors.Is(err, ErrElementTypeMismatch))
+
+	d, err = Get[time.Duration](gmap, "timeout", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, d)
+}
+
 func TestSlice(t *testing.T) {
 	var gmap Map
 
@@ -425,6 +653,41 @@ func TestFromUrlValues(t *testing.T) {
 	assert.Equal(t, "easy there", nestedMap["deeper"])
 }
 
+func TestFromUrlValuesArrays(t *testing.T) {
+	var gmap Map
+
+	uv := url.Values{}
+	uv["items[0][name]"] = []string{"a"}
+	uv["items[1][name]"] = []string{"b"}
+	uv["items[1][qty]"] = []string{"2"}
+	uv["tags[]"] = []string{"x", "y"}
+	uv["one[]"] = []string{"only"}
+	uv["rows[][id]"] = []string{"1", "2"}
+	uv["rows[][label]"] = []string{"first", "second"}
+	uv["sparse[5]"] = []string{"five"}
+	uv["sparse[2]"] = []string{"two"}
+	uv["grid[0][]"] = []string{"p", "q"}
+
+	gmap = Map{}
+	gmap.FromUrlValues(uv)
+	assert.Equal(t, []interface{}{
+		Map{"name": "a"},
+		Map{"name": "b", "qty": "2"},
+	}, gmap["items"])
+	assert.Equal(t, []interface{}{"x", "y"}, gmap["tags"])
+	assert.Equal(t, []interface{}{"only"}, gmap["one"])
+	assert.Equal(t, []interface{}{
+		Map{"id": "1", "label": "first"},
+		Map{"id": "2", "label": "second"},
+	}, gmap["rows"])
+	assert.Equal(t, []interface{}{"two", "five"}, gmap["sparse"])
+	assert.Equal(t, []interface{}{[]interface{}{"p", "q"}}, gmap["grid"])
+
+	name, err := gmap.StringAt("items[1].name", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", name)
+}
+
 func TestFromKeysValues(t *testing.T) {
 	var gmap Map
 
@@ -438,7 +701,7 @@ func TestFromKeysValues(t *testing.T) {
 	assert.Equal(t, "foobar", value)
 
 	value, err = gmap.String("extra", "")
-	assert.Equal(t, ErrKeyDoesNotExist, err)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
 	assert.Equal(t, "", value)
 
 	valueInt, err := gmap.Int("age", 0)
@@ -1,11 +1,22 @@
 package gmap
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Helper function to convert an interface{} to string
 func interfaceToString(v interface{}, def string) (string, error) {
+	if isNilBig(v) {
+		return def, ErrNilValue
+	}
+
 	switch v.(type) {
 	case string:
 		return v.(string), nil
@@ -33,6 +44,16 @@ func interfaceToString(v interface{}, def string) (string, error) {
 		return strconv.FormatUint(uint64(v.(uint32)), 10), nil
 	case uint64:
 		return strconv.FormatUint(v.(uint64), 10), nil
+	case json.Number:
+		return v.(json.Number).String(), nil
+	case *big.Int:
+		return v.(*big.Int).String(), nil
+	case *big.Float:
+		return v.(*big.Float).Text('f', -1), nil
+	case *big.Rat:
+		return v.(*big.Rat).RatString(), nil
+	case Decimal:
+		return v.(Decimal).String(), nil
 	default:
 		return def, ErrTypeMismatch
 	}
@@ -73,13 +94,194 @@ func interfaceToInt(v interface{}, def int) (int, error) {
 			i = 1
 		}
 		return i, nil
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+		// same as the number they hold, so fractions are truncated
+		if i, err := interfaceToInt64(v, 0); err == nil {
+			return int(i), nil
+		}
+		f, err := interfaceToFloat64(v, 0)
+		if err != nil {
+			return def, err
+		}
+		return int(f), nil
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert a float64 to int64 without losing precision.
+func floatToInt64(f float64, def int64) (int64, error) {
+	if f != math.Trunc(f) {
+		return def, ErrPrecisionLoss
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return def, ErrOverflow
+	}
+	return int64(f), nil
+}
+
+// Helper function to convert an interface{} to int64.
+// Unlike interfaceToInt, values that do not fit or have a fractional part are rejected instead of truncated.
+func interfaceToInt64(v interface{}, def int64) (int64, error) {
+	switch v.(type) {
+	case int:
+		return int64(v.(int)), nil
+	case int8:
+		return int64(v.(int8)), nil
+	case int16:
+		return int64(v.(int16)), nil
+	case int32:
+		return int64(v.(int32)), nil
+	case int64:
+		return v.(int64), nil
+	case uint:
+		if uint64(v.(uint)) > math.MaxInt64 {
+			return def, ErrOverflow
+		}
+		return int64(v.(uint)), nil
+	case uint8:
+		return int64(v.(uint8)), nil
+	case uint16:
+		return int64(v.(uint16)), nil
+	case uint32:
+		return int64(v.(uint32)), nil
+	case uint64:
+		if v.(uint64) > math.MaxInt64 {
+			return def, ErrOverflow
+		}
+		return int64(v.(uint64)), nil
+	case float32:
+		return floatToInt64(float64(v.(float32)), def)
+	case float64:
+		return floatToInt64(v.(float64), def)
+	case string:
+		i, err := strconv.ParseInt(v.(string), 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return def, ErrOverflow
+		}
+		if err != nil {
+			return def, err
+		}
+		return i, nil
+	case bool:
+		var i int64
+		if v.(bool) {
+			i = 1
+		}
+		return i, nil
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+		b, err := interfaceToBigInt(v, nil)
+		if err != nil {
+			return def, err
+		}
+		if !b.IsInt64() {
+			return def, ErrOverflow
+		}
+		return b.Int64(), nil
 	default:
 		return def, ErrTypeMismatch
 	}
 }
 
+// Helper function to convert an interface{} to uint64.
+// Negative values, values that do not fit and values that have a fractional part are rejected.
+func interfaceToUint64(v interface{}, def uint64) (uint64, error) {
+	switch v.(type) {
+	case uint:
+		return uint64(v.(uint)), nil
+	case uint8:
+		return uint64(v.(uint8)), nil
+	case uint16:
+		return uint64(v.(uint16)), nil
+	case uint32:
+		return uint64(v.(uint32)), nil
+	case uint64:
+		return v.(uint64), nil
+	case float32, float64:
+		f, _ := interfaceToFloat64(v, 0)
+		if f != math.Trunc(f) {
+			return def, ErrPrecisionLoss
+		}
+		if f < 0 || f >= math.MaxUint64 {
+			return def, ErrOverflow
+		}
+		return uint64(f), nil
+	case string:
+		u, err := strconv.ParseUint(v.(string), 10, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return def, ErrOverflow
+		}
+		if err != nil {
+			// a negative number is valid, it just does not fit
+			if _, e := strconv.ParseInt(v.(string), 10, 64); e == nil || errors.Is(e, strconv.ErrRange) {
+				return def, ErrOverflow
+			}
+			return def, err
+		}
+		return u, nil
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+		b, err := interfaceToBigInt(v, nil)
+		if err != nil {
+			return def, err
+		}
+		if !b.IsUint64() {
+			return def, ErrOverflow
+		}
+		return b.Uint64(), nil
+	}
+
+	i, err := interfaceToInt64(v, 0)
+	if err != nil {
+		return def, err
+	}
+	if i < 0 {
+		return def, ErrOverflow
+	}
+	return uint64(i), nil
+}
+
+// Helper function to convert an interface{} to int32, rejecting values that do not fit.
+func interfaceToInt32(v interface{}, def int32) (int32, error) {
+	i, err := interfaceToInt64(v, 0)
+	if err != nil {
+		return def, err
+	}
+	if i < math.MinInt32 || i > math.MaxInt32 {
+		return def, ErrOverflow
+	}
+	return int32(i), nil
+}
+
+// Helper function to convert an interface{} to uint32, rejecting values that do not fit.
+func interfaceToUint32(v interface{}, def uint32) (uint32, error) {
+	u, err := interfaceToUint64(v, 0)
+	if err != nil {
+		return def, err
+	}
+	if u > math.MaxUint32 {
+		return def, ErrOverflow
+	}
+	return uint32(u), nil
+}
+
+// Helper function to convert an interface{} to int, rejecting values that do not fit or have a fractional part.
+func interfaceToExactInt(v interface{}, def int) (int, error) {
+	i, err := interfaceToInt64(v, 0)
+	if err != nil {
+		return def, err
+	}
+	if i < math.MinInt || i > math.MaxInt {
+		return def, ErrOverflow
+	}
+	return int(i), nil
+}
+
 // Helper function to convert an interface{} to float64
 func interfaceToFloat64(v interface{}, def float64) (float64, error) {
+	if isNilBig(v) {
+		return def, ErrNilValue
+	}
+
 	switch v.(type) {
 	case uint:
 		return float64(v.(uint)), nil
@@ -113,7 +315,580 @@ func interfaceToFloat64(v interface{}, def float64) (float64, error) {
 		return f, nil
 	case string:
 		return strconv.ParseFloat(v.(string), 64)
+	case json.Number:
+		return v.(json.Number).Float64()
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(v.(*big.Int)).Float64()
+		return f, nil
+	case *big.Float:
+		f, _ := v.(*big.Float).Float64()
+		return f, nil
+	case *big.Rat:
+		f, _ := v.(*big.Rat).Float64()
+		return f, nil
+	case Decimal:
+		return v.(Decimal).Float64(), nil
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to check for a nil *big.Int, *big.Float or *big.Rat, which has no value to convert.
+func isNilBig(v interface{}) bool {
+	switch v.(type) {
+	case *big.Int:
+		return v.(*big.Int) == nil
+	case *big.Float:
+		return v.(*big.Float) == nil
+	case *big.Rat:
+		return v.(*big.Rat) == nil
+	default:
+		return false
+	}
+}
+
+// Helper function to convert an interface{} to *big.Int.
+// Always returns a new *big.Int. Values that have a fractional part are rejected.
+func interfaceToBigInt(v interface{}, def *big.Int) (*big.Int, error) {
+	if isNilBig(v) {
+		return def, ErrNilValue
+	}
+
+	switch v.(type) {
+	case int, int8, int16, int32, int64:
+		i, _ := interfaceToInt64(v, 0)
+		return big.NewInt(i), nil
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := interfaceToUint64(v, 0)
+		return new(big.Int).SetUint64(u), nil
+	case float32, float64:
+		f, _ := interfaceToFloat64(v, 0)
+		if math.IsInf(f, 0) {
+			return def, ErrOverflow
+		}
+		if f != math.Trunc(f) {
+			return def, ErrPrecisionLoss
+		}
+		b, _ := big.NewFloat(f).Int(nil)
+		return b, nil
+	case string, json.Number:
+		s, _ := interfaceToString(v, "")
+		if b, ok := new(big.Int).SetString(s, 10); ok {
+			return b, nil
+		}
+		// also accept integers written as '1e3' or '2.0'
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return def, &strconv.NumError{Func: "ParseInt", Num: s, Err: strconv.ErrSyntax}
+		}
+		return interfaceToBigInt(r, def)
+	case *big.Int:
+		return new(big.Int).Set(v.(*big.Int)), nil
+	case *big.Float:
+		f := v.(*big.Float)
+		if f.IsInf() {
+			return def, ErrOverflow
+		}
+		if !f.IsInt() {
+			return def, ErrPrecisionLoss
+		}
+		b, _ := f.Int(nil)
+		return b, nil
+	case *big.Rat:
+		r := v.(*big.Rat)
+		if !r.IsInt() {
+			return def, ErrPrecisionLoss
+		}
+		return new(big.Int).Set(r.Num()), nil
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to *big.Float.
+// Always returns a new *big.Float, with enough precision for the digits of strings and json.Number.
+func interfaceToBigFloat(v interface{}, def *big.Float) (*big.Float, error) {
+	if isNilBig(v) {
+		return def, ErrNilValue
+	}
+
+	switch v.(type) {
+	case int, int8, int16, int32, int64:
+		i, _ := interfaceToInt64(v, 0)
+		return new(big.Float).SetInt64(i), nil
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := interfaceToUint64(v, 0)
+		return new(big.Float).SetUint64(u), nil
+	case float32, float64:
+		f, _ := interfaceToFloat64(v, 0)
+		if math.IsNaN(f) {
+			return def, ErrTypeMismatch
+		}
+		return big.NewFloat(f), nil
+	case string, json.Number:
+		s, _ := interfaceToString(v, "")
+		// about 3.3 bits per decimal digit, so 4 bits per character is always enough
+		prec := uint(len(s))*4 + 64
+		f, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+		if err != nil {
+			return def, err
+		}
+		return f, nil
+	case *big.Int:
+		return new(big.Float).SetInt(v.(*big.Int)), nil
+	case *big.Float:
+		return new(big.Float).Copy(v.(*big.Float)), nil
+	case *big.Rat:
+		r := v.(*big.Rat)
+		prec := uint(r.Num().BitLen()+r.Denom().BitLen()) + 64
+		return new(big.Float).SetPrec(prec).SetRat(r), nil
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to bool
+func interfaceToBool(v interface{}, def bool) (bool, error) {
+	switch v.(type) {
+	case bool:
+		return v.(bool), nil
+	case string:
+		return strconv.ParseBool(v.(string))
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to time.Time, using DefaultTimeParser
+func interfaceToTime(v interface{}, def time.Time) (time.Time, error) {
+	return interfaceToTimeWithParser(v, def, DefaultTimeParser)
+}
+
+// Helper function to convert an interface{} to time.Time, using the given parser
+func interfaceToTimeWithParser(v interface{}, def time.Time, parser *TimeParser) (time.Time, error) {
+	if parser == nil {
+		parser = DefaultTimeParser
+	}
+
+	t, err := parser.Parse(v)
+	if err != nil {
+		return def, err
+	}
+	return t, nil
+}
+
+// Helper function to convert an interface{} to Map
+func interfaceToMap(v interface{}, def Map) (Map, error) {
+	switch v.(type) {
+	case map[string]interface{}:
+		return Map(v.(map[string]interface{})), nil
+	case map[interface{}]interface{}:
+		mp := Map{}
+		mi := v.(map[interface{}]interface{})
+		for k, val := range mi {
+			ks, err := interfaceToString(k, "")
+			if err != nil {
+				return def, ErrTypeMismatch
+			}
+			mp[ks] = val
+		}
+		return mp, nil
+	case Map:
+		return v.(Map), nil
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to []interface{}
+func interfaceToArray(v interface{}, def []interface{}) ([]interface{}, error) {
+	switch v.(type) {
+	case []interface{}:
+		return v.([]interface{}), nil
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to []string
+func interfaceToStringArray(v interface{}, def []string) ([]string, error) {
+	var err error
+	var sa []string
+	switch v.(type) {
+	case []interface{}:
+		val := v.([]interface{})
+		sa = make([]string, len(val))
+		for i, e := range val {
+			sa[i], err = interfaceToString(e, "")
+			if err != nil {
+				return def, ErrElementTypeMismatch
+			}
+		}
+		return sa, nil
+
+	case []string:
+		val := v.([]string)
+		sa = make([]string, len(val))
+		copy(sa, val)
+		return sa, nil
+
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to []float64
+func interfaceToFloat64Array(v interface{}, def []float64) ([]float64, error) {
+	var err error
+	var fa []float64
+	switch v.(type) {
+	case []interface{}:
+		val := v.([]interface{})
+		fa = make([]float64, len(val))
+		for i, e := range val {
+			fa[i], err = interfaceToFloat64(e, 0.0)
+			if err != nil {
+				return def, ErrElementTypeMismatch
+			}
+		}
+		return fa, nil
+
+	case []float64:
+		val := v.([]float64)
+		fa = make([]float64, len(val))
+		copy(fa, val)
+		return fa, nil
+
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to convert an interface{} to []int
+func interfaceToIntArray(v interface{}, def []int) ([]int, error) {
+	var err error
+	var ia []int
+	switch v.(type) {
+	case []interface{}:
+		val := v.([]interface{})
+		ia = make([]int, len(val))
+		for i, e := range val {
+			ia[i], err = interfaceToInt(e, 0)
+			if err != nil {
+				return def, ErrElementTypeMismatch
+			}
+		}
+		return ia, nil
+
+	case []int:
+		val := v.([]int)
+		ia = make([]int, len(val))
+		copy(ia, val)
+		return ia, nil
+
+	default:
+		return def, ErrTypeMismatch
+	}
+}
+
+// Helper function to copy nested Maps, maps, slices and arrays so the copy shares no containers with v.
+// Nil maps and slices stay nil.
+func deepCopy(v interface{}) interface{} {
+	switch v.(type) {
+	case Map:
+		if v.(Map) == nil {
+			return v
+		}
+		mp := make(Map, len(v.(Map)))
+		for k, val := range v.(Map) {
+			mp[k] = deepCopy(val)
+		}
+		return mp
+	case map[string]interface{}:
+		if v.(map[string]interface{}) == nil {
+			return v
+		}
+		mp := make(map[string]interface{}, len(v.(map[string]interface{})))
+		for k, val := range v.(map[string]interface{}) {
+			mp[k] = deepCopy(val)
+		}
+		return mp
+	case map[interface{}]interface{}:
+		if v.(map[interface{}]interface{}) == nil {
+			return v
+		}
+		mi := make(map[interface{}]interface{}, len(v.(map[interface{}]interface{})))
+		for k, val := range v.(map[interface{}]interface{}) {
+			mi[k] = deepCopy(val)
+		}
+		return mi
+	case []interface{}:
+		if v.([]interface{}) == nil {
+			return v
+		}
+		arr := make([]interface{}, len(v.([]interface{})))
+		for i, val := range v.([]interface{}) {
+			arr[i] = deepCopy(val)
+		}
+		return arr
+	case time.Time:
+		return v
+	}
+
+	// other maps and slices, such as []string or map[string]int
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			setCopy(cp.Index(i), rv.Index(i))
+		}
+		return cp.Interface()
+	case reflect.Array:
+		cp := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			setCopy(cp.Index(i), rv.Index(i))
+		}
+		return cp.Interface()
+	case reflect.Map:
+		if rv.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			setCopy(elem, iter.Value())
+			cp.SetMapIndex(iter.Key(), elem)
+		}
+		return cp.Interface()
+	default:
+		return v
+	}
+}
+
+// Helper function to store a deep copy of src in dst
+func setCopy(dst, src reflect.Value) {
+	if c := deepCopy(src.Interface()); c != nil {
+		dst.Set(reflect.ValueOf(c))
+	}
+}
+
+// Helper function to convert an interface{} to Decimal.
+// Floats are converted from their shortest decimal representation, so 19.99 stays 19.99.
+// Returns ErrPrecisionLoss for a *big.Rat that has no finite decimal representation, such as 1/3.
+func interfaceToDecimal(v interface{}, def Decimal) (Decimal, error) {
+	if isNilBig(v) {
+		return def, ErrNilValue
+	}
+
+	switch v.(type) {
+	case Decimal:
+		return v.(Decimal), nil
+	case int, int8, int16, int32, int64:
+		i, _ := interfaceToInt64(v, 0)
+		return Decimal{units: big.NewInt(i)}, nil
+	case uint, uint8, uint16, uint32, uint64:
+		u, _ := interfaceToUint64(v, 0)
+		return Decimal{units: new(big.Int).SetUint64(u)}, nil
+	case float32, float64:
+		bits := 64
+		if _, ok := v.(float32); ok {
+			bits = 32
+		}
+		f, _ := interfaceToFloat64(v, 0)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return def, ErrTypeMismatch
+		}
+		return ParseDecimal(strconv.FormatFloat(f, 'f', -1, bits))
+	case string, json.Number:
+		s, _ := interfaceToString(v, "")
+		d, err := ParseDecimal(s)
+		if err != nil {
+			return def, err
+		}
+		return d, nil
+	case *big.Int:
+		return Decimal{units: new(big.Int).Set(v.(*big.Int))}, nil
+	case *big.Float:
+		f := v.(*big.Float)
+		if f.IsInf() {
+			return def, ErrTypeMismatch
+		}
+		return ParseDecimal(f.Text('g', -1))
+	case *big.Rat:
+		r := v.(*big.Rat)
+		// a fraction has a finite decimal representation only if its denominator has no factors but 2 and 5
+		den := new(big.Int).Set(r.Denom())
+		twos, fives := 0, 0
+		for den.Bit(0) == 0 {
+			den.Rsh(den, 1)
+			twos++
+		}
+		five := big.NewInt(5)
+		q, m := new(big.Int), new(big.Int)
+		for {
+			q.QuoRem(den, five, m)
+			if m.Sign() != 0 {
+				break
+			}
+			den.Set(q)
+			fives++
+		}
+		if den.Cmp(big.NewInt(1)) != 0 {
+			return def, ErrPrecisionLoss
+		}
+
+		scale := twos
+		if fives > scale {
+			scale = fives
+		}
+		units := new(big.Int).Mul(r.Num(), pow10(scale))
+		return Decimal{units: units.Quo(units, r.Denom()), scale: scale}, nil
 	default:
 		return def, ErrTypeMismatch
 	}
 }
+
+// Parses an ISO 8601 duration such as 'PT5M', 'P1DT12H' or '-PT1.5S'.
+// Years and months are rejected because their length varies, while a day is always 24 hours.
+func parseISO8601Duration(s string) (time.Duration, error) {
+	rest := s
+	sign := 1.0
+	if strings.HasPrefix(rest, "-") {
+		sign, rest = -1, rest[1:]
+	} else if strings.HasPrefix(rest, "+") {
+		rest = rest[1:]
+	}
+
+	if !strings.HasPrefix(rest, "P") || len(rest) == 1 {
+		return 0, ErrInvalidDuration
+	}
+	rest = rest[1:]
+
+	units := map[byte]float64{
+		'W': float64(7 * 24 * time.Hour),
+		'D': float64(24 * time.Hour),
+	}
+	timeUnits := map[byte]float64{
+		'H': float64(time.Hour),
+		'M': float64(time.Minute),
+		'S': float64(time.Second),
+	}
+
+	total := 0.0
+	inTime := false
+	for len(rest) > 0 {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return 0, ErrInvalidDuration
+			}
+			inTime, units = true, timeUnits
+			rest = rest[1:]
+			continue
+		}
+
+		i := strings.IndexFunc(rest, func(c rune) bool {
+			return (c < '0' || c > '9') && c != '.'
+		})
+		if i <= 0 {
+			return 0, ErrInvalidDuration
+		}
+
+		n, err := strconv.ParseFloat(rest[:i], 64)
+		unit, ok := units[rest[i]]
+		if err != nil || !ok {
+			return 0, ErrInvalidDuration
+		}
+		total += n * unit
+		rest = rest[i+1:]
+	}
+
+	return floatToDuration(sign * total)
+}
+
+// Helper function to convert nanoseconds to time.Duration, rounding to the nearest nanosecond.
+func floatToDuration(f float64) (time.Duration, error) {
+	f = math.Round(f)
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, ErrOverflow
+	}
+	return time.Duration(f), nil
+}
+
+// Helper function to convert an interface{} to time.Duration.
+// Strings can be in time.ParseDuration or ISO 8601 format, and numbers, including numeric strings, are in units of unit.
+func interfaceToDuration(v interface{}, def time.Duration, unit time.Duration) (time.Duration, error) {
+	switch v.(type) {
+	case time.Duration:
+		return v.(time.Duration), nil
+
+	case string:
+		s := strings.TrimSpace(v.(string))
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+		if d, err := parseISO8601Duration(s); err == nil {
+			return d, nil
+		}
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return interfaceToDuration(json.Number(s), def, unit)
+		}
+		return def, ErrInvalidDuration
+
+	case bool:
+		return def, ErrTypeMismatch
+
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number, *big.Int:
+		// whole numbers are multiplied exactly, so large nanosecond counts do not lose precision
+		if i, err := interfaceToInt64(v, 0); err == nil {
+			d := time.Duration(i) * unit
+			if unit != 0 && d/unit != time.Duration(i) {
+				return def, ErrOverflow
+			}
+			return d, nil
+		}
+	}
+
+	f, err := interfaceToFloat64(v, 0)
+	if err != nil {
+		return def, err
+	}
+	d, err := floatToDuration(f * float64(unit))
+	if err != nil {
+		return def, err
+	}
+	return d, nil
+}
+
+// Helper function to convert an interface{} to []time.Duration
+func interfaceToDurationArray(v interface{}, def []time.Duration, unit time.Duration) ([]time.Duration, error) {
+	switch v.(type) {
+	case []time.Duration:
+		val := v.([]time.Duration)
+		da := make([]time.Duration, len(val))
+		copy(da, val)
+		return da, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return def, ErrTypeMismatch
+	}
+
+	var err error
+	da := make([]time.Duration, rv.Len())
+	for i := range da {
+		da[i], err = interfaceToDuration(rv.Index(i).Interface(), 0, unit)
+		if err == ErrOverflow {
+			return def, err
+		}
+		if err != nil {
+			return def, ErrElementTypeMismatch
+		}
+	}
+	return da, nil
+}
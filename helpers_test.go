@@ -1,7 +1,12 @@
 package gmap
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -103,6 +108,100 @@ func TestInterfaceToInt(t *testing.T) {
 	assert.Equal(t, -1, i)
 }
 
+func TestInterfaceToInt64(t *testing.T) {
+	var v interface{}
+	var i int64
+	var err error
+
+	v = "9223372036854775807"
+	i, err = interfaceToInt64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(math.MaxInt64), i)
+
+	v = "9223372036854775808"
+	i, err = interfaceToInt64(v, -1)
+	assert.Equal(t, ErrOverflow, err)
+	assert.Equal(t, int64(-1), i)
+
+	v = uint64(math.MaxUint64)
+	_, err = interfaceToInt64(v, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	v = 3.0
+	i, err = interfaceToInt64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), i)
+
+	v = 3.9
+	i, err = interfaceToInt64(v, -1)
+	assert.Equal(t, ErrPrecisionLoss, err)
+	assert.Equal(t, int64(-1), i)
+
+	v = 1e19
+	_, err = interfaceToInt64(v, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	v = math.NaN()
+	_, err = interfaceToInt64(v, 0)
+	assert.Equal(t, ErrPrecisionLoss, err)
+
+	v = []string{}
+	_, err = interfaceToInt64(v, 0)
+	assert.Equal(t, ErrTypeMismatch, err)
+}
+
+func TestInterfaceToUint64(t *testing.T) {
+	var v interface{}
+	var u uint64
+	var err error
+
+	v = "18446744073709551615"
+	u, err = interfaceToUint64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u)
+
+	v = "-1"
+	_, err = interfaceToUint64(v, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	v = "abc"
+	_, err = interfaceToUint64(v, 0)
+	assert.NotNil(t, err)
+	assert.NotEqual(t, ErrOverflow, err)
+
+	v = -1
+	_, err = interfaceToUint64(v, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	v = float32(2.5)
+	_, err = interfaceToUint64(v, 0)
+	assert.Equal(t, ErrPrecisionLoss, err)
+
+	v = true
+	u, err = interfaceToUint64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), u)
+}
+
+func TestInterfaceToInt32(t *testing.T) {
+	i, err := interfaceToInt32(int64(math.MaxInt32), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(math.MaxInt32), i)
+
+	_, err = interfaceToInt32(int64(math.MaxInt32)+1, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	_, err = interfaceToInt32(int64(math.MinInt32)-1, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	u, err := interfaceToUint32("4294967295", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(math.MaxUint32), u)
+
+	_, err = interfaceToUint32("4294967296", 0)
+	assert.Equal(t, ErrOverflow, err)
+}
+
 func TestInterfaceToFloat64(t *testing.T) {
 	var v interface{}
 	var f float64
@@ -138,3 +237,186 @@ func TestInterfaceToFloat64(t *testing.T) {
 	assert.Equal(t, ErrTypeMismatch, err)
 	assert.Equal(t, -1.0, f)
 }
+
+func TestInterfaceToJSONNumber(t *testing.T) {
+	var v interface{}
+
+	v = json.Number("9007199254740993")
+	i64, err := interfaceToInt64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), i64)
+
+	s, err := interfaceToString(v, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "9007199254740993", s)
+
+	v = json.Number("2.5")
+	i, err := interfaceToInt(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, i)
+
+	_, err = interfaceToInt64(v, 0)
+	assert.Equal(t, ErrPrecisionLoss, err)
+
+	f, err := interfaceToFloat64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2.5, f)
+
+	v = json.Number("1e3")
+	i64, err = interfaceToInt64(v, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), i64)
+
+	v = json.Number("-1")
+	_, err = interfaceToUint64(v, 0)
+	assert.Equal(t, ErrOverflow, err)
+}
+
+func TestInterfaceToBigInt(t *testing.T) {
+	var v interface{}
+
+	v = "123456789012345678901234567890"
+	b, err := interfaceToBigInt(v, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012345678901234567890", b.String())
+
+	_, err = interfaceToInt64(b, 0)
+	assert.Equal(t, ErrOverflow, err)
+
+	s, err := interfaceToString(b, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "123456789012345678901234567890", s)
+
+	b, err = interfaceToBigInt(uint64(math.MaxUint64), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "18446744073709551615", b.String())
+
+	b, err = interfaceToBigInt(big.NewRat(4, 2), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), b.Int64())
+
+	_, err = interfaceToBigInt(big.NewRat(1, 3), nil)
+	assert.Equal(t, ErrPrecisionLoss, err)
+
+	_, err = interfaceToBigInt(1.5, nil)
+	assert.Equal(t, ErrPrecisionLoss, err)
+
+	def := big.NewInt(-1)
+	b, err = interfaceToBigInt("abc", def)
+	assert.NotNil(t, err)
+	assert.Equal(t, def, b)
+
+	original := big.NewInt(5)
+	b, _ = interfaceToBigInt(original, nil)
+	b.SetInt64(6)
+	assert.Equal(t, int64(5), original.Int64())
+}
+
+func TestInterfaceToBigFloat(t *testing.T) {
+	v := json.Number("12345678901234567890.123456789")
+	f, err := interfaceToBigFloat(v, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "12345678901234567890.123456789", f.Text('f', 9))
+
+	f, err = interfaceToBigFloat(big.NewInt(7), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "7", f.Text('f', -1))
+
+	f, err = interfaceToBigFloat(big.NewRat(1, 4), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "0.25", f.Text('f', -1))
+
+	fl, err := interfaceToFloat64(big.NewFloat(1.5), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1.5, fl)
+
+	_, err = interfaceToBigFloat(math.NaN(), nil)
+	assert.Equal(t, ErrTypeMismatch, err)
+
+	_, err = interfaceToBigFloat(true, nil)
+	assert.Equal(t, ErrTypeMismatch, err)
+}
+
+func TestNilBigNumbers(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"int": (*big.Int)(nil), "float": (*big.Float)(nil), "rat": (*big.Rat)(nil)}
+
+	for key := range gmap {
+		_, err = gmap.String(key, "")
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.Int(key, 0)
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.Int64(key, 0)
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.Float(key, 0)
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.BigInt(key, nil)
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.BigFloat(key, nil)
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.Decimal(key, Decimal{})
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.Duration(key, 0)
+		assert.True(t, errors.Is(err, ErrNilValue))
+		_, err = gmap.Time(key, time.Time{})
+		assert.NotNil(t, err)
+	}
+}
+
+func TestParseISO8601Duration(t *testing.T) {
+	for s, expected := range map[string]time.Duration{
+		"PT5M":       5 * time.Minute,
+		"PT1H30M":    90 * time.Minute,
+		"P1DT12H":    36 * time.Hour,
+		"P2W":        14 * 24 * time.Hour,
+		"PT1.5S":     1500 * time.Millisecond,
+		"-PT10S":     -10 * time.Second,
+		"PT0S":       0,
+		"P1DT1H1M1S": 24*time.Hour + time.Hour + time.Minute + time.Second,
+	} {
+		d, err := parseISO8601Duration(s)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, d)
+	}
+
+	for _, s := range []string{"", "P", "PT", "5M", "P1Y", "P1M", "PT5", "PTM", "P1H", "PT1HT1M"} {
+		_, err := parseISO8601Duration(s)
+		assert.Equal(t, ErrInvalidDuration, err)
+	}
+}
+
+func TestInterfaceToDuration(t *testing.T) {
+	for v, expected := range map[interface{}]time.Duration{
+		"30s":             30 * time.Second,
+		"1h2m":            62 * time.Minute,
+		"PT5M":            5 * time.Minute,
+		"2.5":             2500 * time.Millisecond,
+		1500:              1500 * time.Second,
+		2.5:               2500 * time.Millisecond,
+		json.Number("3"):  3 * time.Second,
+		time.Duration(42): 42,
+	} {
+		d, err := interfaceToDuration(v, 0, time.Second)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, d)
+	}
+
+	d, err := interfaceToDuration(1500, 0, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	d, err = interfaceToDuration("soon", -1, time.Second)
+	assert.Equal(t, ErrInvalidDuration, err)
+	assert.Equal(t, time.Duration(-1), d)
+
+	_, err = interfaceToDuration(int64(math.MaxInt64), 0, time.Second)
+	assert.Equal(t, ErrOverflow, err)
+
+	_, err = interfaceToDuration(1e300, 0, time.Second)
+	assert.Equal(t, ErrOverflow, err)
+
+	_, err = interfaceToDuration(true, 0, time.Second)
+	assert.Equal(t, ErrTypeMismatch, err)
+}
@@ -1,5 +1,10 @@
 package gmap
 
+import (
+	"reflect"
+	"sort"
+)
+
 // MergeFunc determines how to merge two maps together when there is a key collision.
 // Values from both maps are passed to the function, with old represents the value from the Map being merged into, and new is the value from the other Map.
 type MergeFunc func(k string, oldValue, newValue interface{}) interface{}
@@ -31,3 +36,193 @@ func (m Map) MergeWithFunc(other Map, mergeFn MergeFunc) Map {
 
 	return mp
 }
+
+// MergeStrategy determines how DeepMergeWithStrategies merges the values at a path when both Maps have it.
+type MergeStrategy int
+
+const (
+	// MergeDeep merges nested Maps key by key, and replaces any other value. This is the default.
+	MergeDeep MergeStrategy = iota
+	// MergeReplace replaces the old value with the new value, even if both are Maps.
+	MergeReplace
+	// MergeAppend appends the new array to the old array. Other values are replaced.
+	MergeAppend
+	// MergeUnion appends the elements of the new array that are not already in the old array. Other values are replaced.
+	MergeUnion
+	// MergeKeepOld keeps the old value.
+	MergeKeepOld
+	// MergeError fails the merge if the old and new values are different.
+	MergeError
+)
+
+type mergeRule struct {
+	path     string
+	segments []string
+	strategy MergeStrategy
+}
+
+type deepMerger struct {
+	rules   []mergeRule
+	mergeFn MergeFunc
+}
+
+// Finds the strategy for a path. Rule segments of '*' match any segment.
+// Rules are sorted so that more specific rules are tried first.
+func (dm *deepMerger) strategy(segments []string) MergeStrategy {
+	for _, rule := range dm.rules {
+		if len(rule.segments) != len(segments) {
+			continue
+		}
+
+		matched := true
+		for i, s := range rule.segments {
+			if s != "*" && s != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.strategy
+		}
+	}
+	return MergeDeep
+}
+
+// Merges other into m, both found at path.
+func (dm *deepMerger) merge(path string, segments []string, m, other Map) (Map, error) {
+	mp := Map{}
+	for k, v := range m {
+		mp[k] = v
+	}
+
+	for k, v := range other {
+		mval, ok := mp[k]
+		if !ok {
+			mp[k] = v
+			continue
+		}
+
+		childPath := joinPath(path, k)
+		childSegments := append(segments[:len(segments):len(segments)], k)
+		merged, err := dm.mergeValue(childPath, childSegments, mval, v)
+		if err != nil {
+			return nil, err
+		}
+		mp[k] = merged
+	}
+
+	return mp, nil
+}
+
+// Merges two values found at the same path in both Maps.
+func (dm *deepMerger) mergeValue(path string, segments []string, oldValue, newValue interface{}) (interface{}, error) {
+	strategy := dm.strategy(segments)
+	switch strategy {
+	case MergeReplace:
+		return newValue, nil
+
+	case MergeKeepOld:
+		return oldValue, nil
+
+	case MergeError:
+		if !reflect.DeepEqual(oldValue, newValue) {
+			return nil, &PathError{Path: path, Segment: segments[len(segments)-1], Err: ErrMergeConflict}
+		}
+		return oldValue, nil
+
+	case MergeAppend, MergeUnion:
+		oldArray, oldErr := interfaceToArray(oldValue, nil)
+		newArray, newErr := interfaceToArray(newValue, nil)
+		if oldErr != nil || newErr != nil {
+			return newValue, nil
+		}
+
+		arr := make([]interface{}, len(oldArray), len(oldArray)+len(newArray))
+		copy(arr, oldArray)
+		for _, e := range newArray {
+			if strategy == MergeUnion && containsValue(arr, e) {
+				continue
+			}
+			arr = append(arr, e)
+		}
+		return arr, nil
+	}
+
+	oldMap, oldErr := interfaceToMap(oldValue, nil)
+	newMap, newErr := interfaceToMap(newValue, nil)
+	if oldErr == nil && newErr == nil {
+		return dm.merge(path, segments, oldMap, newMap)
+	}
+
+	return dm.mergeFn(path, oldValue, newValue), nil
+}
+
+// Counts the '*' segments of a path.
+func wildcards(segments []string) int {
+	n := 0
+	for _, s := range segments {
+		if s == "*" {
+			n++
+		}
+	}
+	return n
+}
+
+// Returns true if arr has an element deeply equal to v.
+func containsValue(arr []interface{}, v interface{}) bool {
+	for _, e := range arr {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merges this Map with another Map, recursing into nested Maps present in both.
+// Other entries with key collisions are overwritten with the values from other Map.
+// Returns a new Map.
+func (m Map) DeepMerge(other Map) Map {
+	return m.DeepMergeWithFunc(other, func(path string, oldValue, newValue interface{}) interface{} {
+		return newValue
+	})
+}
+
+// Merges this Map with another Map, recursing into nested Maps present in both.
+// Other entries with key collisions are merged with a custom merge function,
+// which receives the full path of the entry, e.g. 'server.tls.port', instead of just the key.
+// Returns a new Map.
+func (m Map) DeepMergeWithFunc(other Map, mergeFn MergeFunc) Map {
+	dm := &deepMerger{mergeFn: mergeFn}
+	mp, _ := dm.merge("", nil, m, other)
+	return mp
+}
+
+// Merges this Map with another Map, recursing into nested Maps present in both.
+// Strategies are keyed by path, e.g. 'servers.*.tags', where '*' matches any key.
+// Entries without a strategy use MergeDeep.
+// Returns a new Map, or a *PathError wrapping ErrMergeConflict if a MergeError path has different values.
+func (m Map) DeepMergeWithStrategies(other Map, strategies map[string]MergeStrategy) (Map, error) {
+	dm := &deepMerger{
+		mergeFn: func(path string, oldValue, newValue interface{}) interface{} {
+			return newValue
+		},
+	}
+
+	for path, strategy := range strategies {
+		segments, err := parsePath(path)
+		if err != nil {
+			return nil, &PathError{Path: path, Err: err}
+		}
+		dm.rules = append(dm.rules, mergeRule{path: path, segments: segments, strategy: strategy})
+	}
+
+	sort.Slice(dm.rules, func(i, j int) bool {
+		wi, wj := wildcards(dm.rules[i].segments), wildcards(dm.rules[j].segments)
+		if wi != wj {
+			return wi < wj
+		}
+		return dm.rules[i].path < dm.rules[j].path
+	})
+
+	return dm.merge("", nil, m, other)
+}
@@ -0,0 +1,63 @@
+package gmap
+
+// Merges patch into target following RFC 7396.
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, err := interfaceToMap(patch, nil)
+	if err != nil {
+		return deepCopy(patch)
+	}
+
+	mp := Map{}
+	if targetMap, err := interfaceToMap(target, nil); err == nil {
+		for k, v := range targetMap {
+			mp[k] = v
+		}
+	}
+
+	for k, v := range patchMap {
+		if v == nil {
+			delete(mp, k)
+			continue
+		}
+		mp[k] = mergePatch(mp[k], v)
+	}
+	return mp
+}
+
+// Applies an RFC 7396 JSON Merge Patch to this Map.
+// Nil values in the patch delete the key, nested Maps are merged recursively,
+// and any other value, including arrays, replaces the existing value.
+// Returns a new Map.
+func (m Map) MergePatch(patch Map) Map {
+	return mergePatch(m, patch).(Map)
+}
+
+// Creates an RFC 7396 JSON Merge Patch that turns one Map into another.
+// Keys missing from the new Map are set to nil, and arrays that differ are included in full.
+// Since nil means deletion, a key whose new value is nil cannot be expressed and is removed instead.
+func CreateMergePatch(from, to Map) Map {
+	c := &comparer{}
+	patch := Map{}
+	for k := range from {
+		if _, ok := to[k]; !ok {
+			patch[k] = nil
+		}
+	}
+
+	for k, v := range to {
+		old, ok := from[k]
+		if ok && c.equal(old, v) {
+			continue
+		}
+
+		oldMap, oldErr := interfaceToMap(old, nil)
+		newMap, newErr := interfaceToMap(v, nil)
+		if ok && oldErr == nil && newErr == nil {
+			patch[k] = CreateMergePatch(oldMap, newMap)
+		} else {
+			patch[k] = deepCopy(v)
+		}
+	}
+
+	return patch
+}
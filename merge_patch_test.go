@@ -0,0 +1,70 @@
+package gmap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Examples from RFC 7396 Appendix A whose original and patch are objects
+var testMergePatchCases = []struct {
+	original string
+	patch    string
+	result   string
+}{
+	{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+	{`{"a":"b"}`, `{"a":null}`, `{}`},
+	{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+	{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+	{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+	{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+	{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+	{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+}
+
+func TestMergePatch(t *testing.T) {
+	for _, tc := range testMergePatchCases {
+		original, patch, result := Map{}, Map{}, Map{}
+		assert.Nil(t, json.Unmarshal([]byte(tc.original), &original))
+		assert.Nil(t, json.Unmarshal([]byte(tc.patch), &patch))
+		assert.Nil(t, json.Unmarshal([]byte(tc.result), &result))
+
+		patched := original.MergePatch(patch)
+		assert.Equal(t, []Change{}, Diff(result, patched), tc.patch)
+	}
+
+	original := Map{"a": Map{"b": "c"}, "list": []interface{}{1}}
+	patched := original.MergePatch(Map{"a": Map{"b": "d"}})
+	assert.Equal(t, Map{"b": "c"}, original["a"])
+	assert.Equal(t, Map{"b": "d"}, patched["a"])
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	from := Map{
+		"title":  "Goodbye!",
+		"author": Map{"givenName": "John", "familyName": "Doe"},
+		"tags":   []interface{}{"example", "sample"},
+		"same":   Map{"x": 1},
+	}
+	to := Map{
+		"title":       "Hello!",
+		"author":      map[string]interface{}{"givenName": "John"},
+		"tags":        []interface{}{"example"},
+		"same":        Map{"x": 1},
+		"phoneNumber": "+01-123-456-7890",
+	}
+
+	patch := CreateMergePatch(from, to)
+	assert.Equal(t, Map{
+		"title":       "Hello!",
+		"author":      Map{"familyName": nil},
+		"tags":        []interface{}{"example"},
+		"phoneNumber": "+01-123-456-7890",
+	}, patch)
+
+	assert.Equal(t, []Change{}, Diff(to, from.MergePatch(patch)))
+	assert.Equal(t, Map{}, CreateMergePatch(from, from))
+}
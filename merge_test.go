@@ -1,6 +1,7 @@
 package gmap
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -23,3 +24,112 @@ func TestMerge(t *testing.T) {
 	assert.Equal(t, "free", mp["beer"])
 	assert.Equal(t, 10, mp["soda"])
 }
+
+func TestDeepMerge(t *testing.T) {
+	var map1 Map
+	var map2 Map
+
+	map1 = Map{
+		"name": "base",
+		"server": Map{
+			"host": "localhost",
+			"tls":  map[string]interface{}{"enabled": false, "port": 443},
+		},
+		"tags": []interface{}{"a"},
+	}
+
+	map2 = Map{
+		"server": Map{
+			"tls":     Map{"enabled": true},
+			"timeout": 30,
+		},
+		"tags": []interface{}{"b"},
+	}
+
+	mp := map1.DeepMerge(map2)
+	assert.Equal(t, "base", mp["name"])
+	assert.Equal(t, []interface{}{"b"}, mp["tags"])
+	assert.Equal(t, Map{
+		"host":    "localhost",
+		"timeout": 30,
+		"tls":     Map{"enabled": true, "port": 443},
+	}, mp["server"])
+
+	// the original maps are left alone
+	assert.Equal(t, map[string]interface{}{"enabled": false, "port": 443}, map1["server"].(Map)["tls"])
+	assert.Equal(t, Map{"tls": Map{"enabled": true}, "timeout": 30}, map2["server"])
+}
+
+func TestDeepMergeWithFunc(t *testing.T) {
+	var map1 Map
+	var map2 Map
+
+	map1 = Map{"limits": Map{"cpu": 2, "memory": 512}, "cpu": 1}
+	map2 = Map{"limits": Map{"cpu": 4, "memory": 256}, "cpu": 3}
+
+	paths := []string{}
+	mp := map1.DeepMergeWithFunc(map2, func(path string, oldValue, newValue interface{}) interface{} {
+		paths = append(paths, path)
+		if oldValue.(int) > newValue.(int) {
+			return oldValue
+		}
+		return newValue
+	})
+
+	assert.Equal(t, Map{"limits": Map{"cpu": 4, "memory": 512}, "cpu": 3}, mp)
+	assert.Contains(t, paths, "limits.cpu")
+	assert.Contains(t, paths, "limits.memory")
+	assert.Contains(t, paths, "cpu")
+}
+
+func TestDeepMergeWithStrategies(t *testing.T) {
+	var map1 Map
+	var map2 Map
+	var err error
+
+	map1 = Map{
+		"servers": Map{
+			"web": Map{"tags": []interface{}{"a", "b"}, "env": Map{"A": "1"}},
+			"db":  Map{"tags": []interface{}{"a"}},
+		},
+		"plugins": []interface{}{"x"},
+		"version": 1,
+		"owner":   "ops",
+	}
+
+	map2 = Map{
+		"servers": Map{
+			"web": Map{"tags": []interface{}{"b", "c"}, "env": Map{"B": "2"}},
+			"db":  Map{"tags": []interface{}{"b"}},
+		},
+		"plugins": []interface{}{"x", "y"},
+		"version": 2,
+		"owner":   "ops",
+	}
+
+	mp, err := map1.DeepMergeWithStrategies(map2, map[string]MergeStrategy{
+		"servers.*.tags":  MergeUnion,
+		"servers.db.tags": MergeReplace,
+		"servers.web.env": MergeReplace,
+		"plugins":         MergeAppend,
+		"version":         MergeKeepOld,
+		"owner":           MergeError,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, mp["servers"].(Map)["web"].(Map)["tags"])
+	assert.Equal(t, Map{"B": "2"}, mp["servers"].(Map)["web"].(Map)["env"])
+	assert.Equal(t, []interface{}{"b"}, mp["servers"].(Map)["db"].(Map)["tags"])
+	assert.Equal(t, []interface{}{"x", "x", "y"}, mp["plugins"])
+	assert.Equal(t, 1, mp["version"])
+	assert.Equal(t, "ops", mp["owner"])
+	assert.Equal(t, []interface{}{"a", "b"}, map1["servers"].(Map)["web"].(Map)["tags"])
+
+	map2["owner"] = "dev"
+	mp, err = map1.DeepMergeWithStrategies(map2, map[string]MergeStrategy{"owner": MergeError})
+	assert.Nil(t, mp)
+	assert.True(t, errors.Is(err, ErrMergeConflict))
+	assert.Equal(t, "owner", err.(*PathError).Path)
+
+	_, err = map1.DeepMergeWithStrategies(map2, map[string]MergeStrategy{"a..b": MergeError})
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+}
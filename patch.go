@@ -0,0 +1,172 @@
+package gmap
+
+import (
+	"encoding/json"
+)
+
+// Operations of an RFC 6902 JSON Patch.
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+	PatchMove    = "move"
+	PatchCopy    = "copy"
+	PatchTest    = "test"
+)
+
+// PatchOperation is a single operation of an RFC 6902 JSON Patch.
+// Path and From are JSON Pointers, e.g. '/items/3/price'.
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON writes only the members used by the operation, so a nil Value is kept for add, replace and test.
+func (op PatchOperation) MarshalJSON() ([]byte, error) {
+	members := map[string]interface{}{"op": op.Op, "path": op.Path}
+	switch op.Op {
+	case PatchMove, PatchCopy:
+		members["from"] = op.From
+	case PatchAdd, PatchReplace, PatchTest:
+		members["value"] = op.Value
+	}
+	return json.Marshal(members)
+}
+
+// Adds a value at a JSON Pointer. Array elements are inserted rather than replaced.
+// The empty pointer replaces the whole document, which must then be a Map.
+func addPointer(doc Map, ptr string, value interface{}) (Map, error) {
+	p, err := ParsePointer(ptr)
+	if err != nil {
+		return nil, &PathError{Path: ptr, Err: err}
+	}
+
+	if len(p) == 0 {
+		mp, err := interfaceToMap(value, nil)
+		if err != nil {
+			return nil, &PathError{Path: ptr, Err: err}
+		}
+		return mp, nil
+	}
+
+	_, err = update(ptr, doc, p, false, func(container interface{}, segment string) (interface{}, error) {
+		return insert(container, segment, value)
+	})
+	return doc, err
+}
+
+// Applies a single operation to doc, which may be replaced entirely.
+func applyOperation(doc Map, op PatchOperation) (Map, error) {
+	switch op.Op {
+	case PatchAdd:
+		return addPointer(doc, op.Path, deepCopy(op.Value))
+
+	case PatchRemove:
+		return doc, doc.DeletePointer(op.Path)
+
+	case PatchReplace:
+		if _, err := doc.GetPointer(op.Path); err != nil {
+			return nil, err
+		}
+		if op.Path == "" {
+			return addPointer(doc, op.Path, deepCopy(op.Value))
+		}
+		return doc, doc.SetPointer(op.Path, deepCopy(op.Value))
+
+	case PatchMove:
+		value, err := doc.GetPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.From == op.Path {
+			return doc, nil
+		}
+		from, _ := ParsePointer(op.From)
+		path, err := ParsePointer(op.Path)
+		if err != nil {
+			return nil, &PathError{Path: op.Path, Err: err}
+		}
+		if hasPrefix(path, from) {
+			return nil, &PathError{Path: op.Path, Err: ErrInvalidPatch}
+		}
+		if err = doc.DeletePointer(op.From); err != nil {
+			return nil, err
+		}
+		return addPointer(doc, op.Path, value)
+
+	case PatchCopy:
+		value, err := doc.GetPointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		return addPointer(doc, op.Path, deepCopy(value))
+
+	case PatchTest:
+		value, err := doc.GetPointer(op.Path)
+		if err != nil {
+			return nil, err
+		}
+		c := &comparer{numeric: true}
+		if !c.equal(value, op.Value) {
+			return nil, &PathError{Path: op.Path, Err: ErrTestFailed}
+		}
+		return doc, nil
+
+	default:
+		return nil, ErrInvalidPatch
+	}
+}
+
+// Applies an RFC 6902 JSON Patch to a copy of this Map.
+// The operations are applied in order, and the patch is atomic: the Map is never modified,
+// and nothing is returned if any operation fails.
+// Returns the patched Map, or a *PatchError naming the operation that failed.
+func (m Map) ApplyPatch(ops []PatchOperation) (Map, error) {
+	var err error
+	doc := deepCopy(m).(Map)
+	for i, op := range ops {
+		doc, err = applyOperation(doc, op)
+		if err != nil {
+			return nil, &PatchError{Index: i, Op: op.Op, Err: err}
+		}
+	}
+
+	return doc, nil
+}
+
+// Creates an RFC 6902 JSON Patch that turns one Map into another.
+// Uses add, remove and replace operations based on Diff.
+func CreatePatch(from, to Map) []PatchOperation {
+	changes := Diff(from, to)
+	ops := make([]PatchOperation, 0, len(changes))
+	for i := 0; i < len(changes); i++ {
+		change := changes[i]
+		switch change.Type {
+		case ChangeAdded:
+			ops = append(ops, PatchOperation{Op: PatchAdd, Path: Pointer(change.Segments).String(), Value: deepCopy(change.NewValue)})
+
+		case ChangeModified:
+			ops = append(ops, PatchOperation{Op: PatchReplace, Path: Pointer(change.Segments).String(), Value: deepCopy(change.NewValue)})
+
+		case ChangeRemoved:
+			// removals from the same parent are emitted last to first, so array indices stay valid
+			j := i
+			for j+1 < len(changes) && changes[j+1].Type == ChangeRemoved && sameParent(changes[j+1].Segments, change.Segments) {
+				j++
+			}
+			for k := j; k >= i; k-- {
+				ops = append(ops, PatchOperation{Op: PatchRemove, Path: Pointer(changes[k].Segments).String()})
+			}
+			i = j
+		}
+	}
+
+	return ops
+}
+
+// Returns true if two paths have the same parent.
+func sameParent(a, b []string) bool {
+	return len(a) == len(b) && hasPrefix(a, b[:len(b)-1])
+}
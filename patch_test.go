@@ -0,0 +1,146 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPatchDocument = `
+{
+ "title": "Goodbye!",
+ "author": { "givenName": "John", "familyName": "Doe" },
+ "tags": ["example", "sample"],
+ "content": "This will be unchanged",
+ "price": 10
+}
+`
+
+const testPatchOperations = `
+[
+ { "op": "test", "path": "/price", "value": 10.0 },
+ { "op": "replace", "path": "/title", "value": "Hello!" },
+ { "op": "add", "path": "/author/phoneNumber", "value": "+01-123-456-7890" },
+ { "op": "remove", "path": "/author/familyName" },
+ { "op": "add", "path": "/tags/0", "value": "first" },
+ { "op": "add", "path": "/tags/-", "value": "last" },
+ { "op": "copy", "from": "/author", "path": "/editor" },
+ { "op": "move", "from": "/content", "path": "/body/text" }
+]
+`
+
+func TestApplyPatch(t *testing.T) {
+	var doc Map
+	var ops []PatchOperation
+	var err error
+
+	doc = Map{}
+	err = json.Unmarshal([]byte(testPatchDocument), &doc)
+	assert.Nil(t, err)
+	err = json.Unmarshal([]byte(testPatchOperations), &ops)
+	assert.Nil(t, err)
+
+	// moving into a missing parent fails, so the whole patch fails
+	patched, err := doc.ApplyPatch(ops)
+	assert.Nil(t, patched)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.Equal(t, 7, err.(*PatchError).Index)
+	assert.Equal(t, "Goodbye!", doc["title"])
+	assert.Equal(t, []interface{}{"example", "sample"}, doc["tags"])
+
+	ops[7].Path = "/body"
+	patched, err = doc.ApplyPatch(ops)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{
+		"title": "Hello!",
+		"author": map[string]interface{}{
+			"givenName":   "John",
+			"phoneNumber": "+01-123-456-7890",
+		},
+		"editor": map[string]interface{}{
+			"givenName":   "John",
+			"phoneNumber": "+01-123-456-7890",
+		},
+		"tags":  []interface{}{"first", "example", "sample", "last"},
+		"body":  "This will be unchanged",
+		"price": 10.0,
+	}, patched)
+
+	// the copy does not share the nested map
+	patched["editor"].(map[string]interface{})["givenName"] = "Jane"
+	assert.Equal(t, "John", patched["author"].(map[string]interface{})["givenName"])
+
+	// the original document is untouched
+	assert.Equal(t, map[string]interface{}{"givenName": "John", "familyName": "Doe"}, doc["author"])
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	doc := Map{"a": Map{"b": []interface{}{1, 2}}, "n": 1}
+
+	_, err := doc.ApplyPatch([]PatchOperation{{Op: PatchTest, Path: "/n", Value: "1"}})
+	assert.True(t, errors.Is(err, ErrTestFailed))
+
+	_, err = doc.ApplyPatch([]PatchOperation{{Op: PatchTest, Path: "/a", Value: Map{"b": []interface{}{1.0, 2.0}}}})
+	assert.Nil(t, err)
+
+	_, err = doc.ApplyPatch([]PatchOperation{{Op: PatchReplace, Path: "/missing", Value: 1}})
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	_, err = doc.ApplyPatch([]PatchOperation{{Op: PatchRemove, Path: "/a/b/2"}})
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	_, err = doc.ApplyPatch([]PatchOperation{{Op: PatchMove, From: "/a", Path: "/a/c"}})
+	assert.True(t, errors.Is(err, ErrInvalidPatch))
+
+	_, err = doc.ApplyPatch([]PatchOperation{{Op: "frobnicate", Path: "/a"}})
+	assert.True(t, errors.Is(err, ErrInvalidPatch))
+	assert.Equal(t, "frobnicate", err.(*PatchError).Op)
+
+	patched, err := doc.ApplyPatch([]PatchOperation{{Op: PatchReplace, Path: "", Value: Map{"new": true}}})
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"new": true}, patched)
+}
+
+func TestCreatePatch(t *testing.T) {
+	from := Map{
+		"title": "Goodbye!",
+		"tags":  []interface{}{"a", "b", "c", "d"},
+		"old":   true,
+		"nested": Map{
+			"value": 1,
+		},
+	}
+	to := Map{
+		"title": "Hello!",
+		"tags":  []interface{}{"a", "x"},
+		"nested": Map{
+			"value": 2,
+			"extra": []interface{}{1},
+		},
+	}
+
+	ops := CreatePatch(from, to)
+	assert.Equal(t, []PatchOperation{
+		{Op: PatchAdd, Path: "/nested/extra", Value: []interface{}{1}},
+		{Op: PatchReplace, Path: "/nested/value", Value: 2},
+		{Op: PatchRemove, Path: "/old"},
+		{Op: PatchReplace, Path: "/tags/1", Value: "x"},
+		{Op: PatchRemove, Path: "/tags/3"},
+		{Op: PatchRemove, Path: "/tags/2"},
+		{Op: PatchReplace, Path: "/title", Value: "Hello!"},
+	}, ops)
+
+	patched, err := from.ApplyPatch(ops)
+	assert.Nil(t, err)
+	assert.Equal(t, to, patched)
+
+	data, err := json.Marshal(ops[2])
+	assert.Nil(t, err)
+	assert.Equal(t, `{"op":"remove","path":"/old"}`, string(data))
+
+	data, err = json.Marshal(PatchOperation{Op: PatchAdd, Path: "/a~1b"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"op":"add","path":"/a~1b","value":null}`, string(data))
+}
@@ -0,0 +1,437 @@
+package gmap
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Splits a path such as 'user.addresses[0].zip' into its segments.
+// Keys are separated by dots, and any segment may also be written in brackets.
+// Bracketed segments may contain dots, e.g. 'hosts[example.com].port'.
+func parsePath(path string) ([]string, error) {
+	if path == "" {
+		return nil, ErrInvalidPath
+	}
+
+	segments := make([]string, 0)
+	expectKey := true
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, ErrInvalidPath
+			}
+			segments = append(segments, path[i+1:i+end])
+			i += end + 1
+			expectKey = false
+
+		case '.':
+			if expectKey {
+				return nil, ErrInvalidPath
+			}
+			i++
+			expectKey = true
+			if i == len(path) || path[i] == '[' {
+				return nil, ErrInvalidPath
+			}
+
+		default:
+			if !expectKey {
+				return nil, ErrInvalidPath
+			}
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segments = append(segments, path[i:i+end])
+			i += end
+			expectKey = false
+		}
+	}
+
+	return segments, nil
+}
+
+// Parses an array index segment.
+// When appending, '-' and the length of the array refer to the position after the last element.
+func arrayIndex(arr []interface{}, segment string, appending bool) (int, error) {
+	if appending && segment == "-" {
+		return len(arr), nil
+	}
+
+	i, err := strconv.Atoi(segment)
+	if err != nil {
+		return 0, ErrTypeMismatch
+	}
+
+	max := len(arr)
+	if appending {
+		max++
+	}
+	if i < 0 || i >= max {
+		return 0, ErrIndexOutOfRange
+	}
+	return i, nil
+}
+
+// Finds the key of a map[interface{}]interface{} whose string form is segment.
+// Returns segment itself if there is no such key.
+func interfaceKey(mi map[interface{}]interface{}, segment string) interface{} {
+	for k := range mi {
+		if ks, err := interfaceToString(k, ""); err == nil && ks == segment {
+			return k
+		}
+	}
+	return segment
+}
+
+// Retrieves the value of a single segment from a Map-like value or an []interface{}.
+func child(container interface{}, segment string) (interface{}, error) {
+	if arr, ok := container.([]interface{}); ok {
+		i, err := arrayIndex(arr, segment, false)
+		if err != nil {
+			return nil, err
+		}
+		return arr[i], nil
+	}
+
+	mp, err := interfaceToMap(container, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := mp[segment]
+	if !ok {
+		return nil, ErrKeyDoesNotExist
+	}
+	return value, nil
+}
+
+// Stores value under a single segment of a Map-like value or an []interface{}.
+// Returns the container, which is a new slice if an array had to grow.
+func assign(container interface{}, segment string, value interface{}) (interface{}, error) {
+	switch container.(type) {
+	case Map:
+		container.(Map)[segment] = value
+		return container, nil
+	case map[string]interface{}:
+		container.(map[string]interface{})[segment] = value
+		return container, nil
+	case map[interface{}]interface{}:
+		mi := container.(map[interface{}]interface{})
+		mi[interfaceKey(mi, segment)] = value
+		return container, nil
+	case []interface{}:
+		arr := container.([]interface{})
+		i, err := arrayIndex(arr, segment, true)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(arr) {
+			return append(arr, value), nil
+		}
+		arr[i] = value
+		return arr, nil
+	case nil:
+		return nil, ErrNilValue
+	default:
+		return nil, ErrTypeMismatch
+	}
+}
+
+// Limits how many nil values put pads an array with, so that a path such as 'a[4611686018427387903]'
+// cannot be used to exhaust memory.
+const maxArrayPadding = 10000
+
+// Like assign, but pads arrays with nil values up to the index being set.
+// Returns ErrIndexOutOfRange if that would add more than maxArrayPadding values.
+func put(container interface{}, segment string, value interface{}) (interface{}, error) {
+	if arr, ok := container.([]interface{}); ok {
+		if i, err := strconv.Atoi(segment); err == nil && i > len(arr) {
+			if i-len(arr) > maxArrayPadding {
+				return nil, ErrIndexOutOfRange
+			}
+			arr = append(arr, make([]interface{}, i-len(arr))...)
+			return append(arr, value), nil
+		}
+	}
+
+	return assign(container, segment, value)
+}
+
+// Like assign, but shifts array elements up to make room for value instead of replacing.
+func insert(container interface{}, segment string, value interface{}) (interface{}, error) {
+	arr, ok := container.([]interface{})
+	if !ok {
+		return assign(container, segment, value)
+	}
+
+	i, err := arrayIndex(arr, segment, true)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]interface{}, 0, len(arr)+1)
+	result = append(result, arr[:i]...)
+	result = append(result, value)
+	return append(result, arr[i:]...), nil
+}
+
+// Creates an empty container suitable for holding segment.
+// Numeric segments create an []interface{}, anything else creates a Map.
+func newContainer(segment string) interface{} {
+	if _, err := strconv.ParseUint(segment, 10, 0); err == nil {
+		return []interface{}{}
+	}
+	return Map{}
+}
+
+// Removes a single segment from a Map-like value or an []interface{}.
+// Returns the container, which is a new slice if an element was removed from an array.
+func remove(container interface{}, segment string) (interface{}, error) {
+	switch container.(type) {
+	case []interface{}:
+		arr := container.([]interface{})
+		i, err := arrayIndex(arr, segment, false)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(arr)-1)
+		result = append(result, arr[:i]...)
+		return append(result, arr[i+1:]...), nil
+	case map[interface{}]interface{}:
+		mi := container.(map[interface{}]interface{})
+		k := interfaceKey(mi, segment)
+		if _, ok := mi[k]; !ok {
+			return nil, ErrKeyDoesNotExist
+		}
+		delete(mi, k)
+		return container, nil
+	}
+
+	mp, err := interfaceToMap(container, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := mp[segment]; !ok {
+		return nil, ErrKeyDoesNotExist
+	}
+	delete(mp, segment)
+	return container, nil
+}
+
+// Walks segments starting from container, returning the value found at the end.
+// Nil values in the middle of the path are reported as ErrNilValue.
+func lookup(path string, container interface{}, segments []string) (interface{}, error) {
+	var err error
+	value := container
+	for i, segment := range segments {
+		value, err = child(value, segment)
+		if err == nil && value == nil && i < len(segments)-1 {
+			err = ErrNilValue
+		}
+		if err != nil {
+			return nil, &PathError{Path: path, Segment: segment, Err: err}
+		}
+	}
+
+	return value, nil
+}
+
+// Walks segments starting from container and applies fn to the container holding the last segment.
+// Containers that change along the way, such as arrays that grew or shrank, are stored back into their parents.
+// If create is true, missing or nil intermediate values are replaced with new containers.
+func update(path string, container interface{}, segments []string, create bool, fn func(container interface{}, segment string) (interface{}, error)) (interface{}, error) {
+	segment := segments[0]
+	if len(segments) == 1 {
+		updated, err := fn(container, segment)
+		if err != nil {
+			return nil, &PathError{Path: path, Segment: segment, Err: err}
+		}
+		return updated, nil
+	}
+
+	next, err := child(container, segment)
+	if create && (err == ErrKeyDoesNotExist || err == ErrIndexOutOfRange || (err == nil && next == nil)) {
+		next, err = newContainer(segments[1]), nil
+	}
+	if err == nil && next == nil {
+		err = ErrNilValue
+	}
+	if err != nil {
+		return nil, &PathError{Path: path, Segment: segment, Err: err}
+	}
+
+	next, err = update(path, next, segments[1:], create, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated interface{}
+	if create {
+		updated, err = put(container, segment, next)
+	} else {
+		updated, err = assign(container, segment, next)
+	}
+	if err != nil {
+		return nil, &PathError{Path: path, Segment: segment, Err: err}
+	}
+	return updated, nil
+}
+
+// Retrieves the value at the given path, e.g. 'user.addresses[0].zip'.
+// Walks through nested Map, map[string]interface{}, map[interface{}]interface{} and []interface{} values.
+// Returns a *PathError naming the failing segment if the path cannot be resolved or the value is nil.
+func (m Map) ValueAt(path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, &PathError{Path: path, Err: err}
+	}
+
+	value, err := lookup(path, m, segments)
+	if err == nil && value == nil {
+		err = &PathError{Path: path, Segment: segments[len(segments)-1], Err: ErrNilValue}
+	}
+	return value, err
+}
+
+// Retrieves another Map at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) MapAt(path string, def Map) (Map, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	mp, err := interfaceToMap(value, def)
+	return mp, newValueError(path, "gmap.Map", value, err)
+}
+
+// Retrieves an array of interface{} at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) ArrayAt(path string, def []interface{}) ([]interface{}, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	arr, err := interfaceToArray(value, def)
+	return arr, newValueError(path, "[]interface {}", value, err)
+}
+
+// Retrieves an int at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) IntAt(path string, def int) (int, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	i, err := interfaceToInt(value, def)
+	return i, newValueError(path, "int", value, err)
+}
+
+// Retrieves a float at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) FloatAt(path string, def float64) (float64, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	f, err := interfaceToFloat64(value, def)
+	return f, newValueError(path, "float64", value, err)
+}
+
+// Retrieves a string at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) StringAt(path string, def string) (string, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	s, err := interfaceToString(value, def)
+	return s, newValueError(path, "string", value, err)
+}
+
+// Retrieves a boolean at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) BooleanAt(path string, def bool) (bool, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	b, err := interfaceToBool(value, def)
+	return b, newValueError(path, "bool", value, err)
+}
+
+// Retrieves a string array at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) StringArrayAt(path string, def []string) ([]string, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	sa, err := interfaceToStringArray(value, def)
+	return sa, newValueError(path, "[]string", value, err)
+}
+
+// Retrieves a float64 array at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) FloatArrayAt(path string, def []float64) ([]float64, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	fa, err := interfaceToFloat64Array(value, def)
+	return fa, newValueError(path, "[]float64", value, err)
+}
+
+// Retrieves an int array at the given path.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) IntArrayAt(path string, def []int) ([]int, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	ia, err := interfaceToIntArray(value, def)
+	return ia, newValueError(path, "[]int", value, err)
+}
+
+// Retrieves time at the given path.
+// Can convert time value if it's a string and in the recognized format.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) TimeAt(path string, def time.Time) (time.Time, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	t, err := interfaceToTime(value, def)
+	return t, newValueError(path, "time.Time", value, err)
+}
+
+// Retrieves time at the given path, but also converts to UTC.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) TimeUTCAt(path string, def time.Time) (time.Time, error) {
+	t, err := m.TimeAt(path, def)
+	return t.UTC(), err
+}
+
+// Retrieves a time.Duration at the given path, converting it the same way as Duration.
+// Returns the default value and a *PathError if the path cannot be resolved, or an *Error if the value cannot be converted.
+func (m Map) DurationAt(path string, def time.Duration) (time.Duration, error) {
+	value, err := m.ValueAt(path)
+	if err != nil {
+		return def, err
+	}
+
+	d, err := interfaceToDuration(value, def, time.Second)
+	return d, newValueError(path, "time.Duration", value, err)
+}
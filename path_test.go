@@ -0,0 +1,173 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPathPayload = `
+{
+ "user": {
+  "name": "John",
+  "age": "42",
+  "addresses": [
+   { "zip": 94105, "city": "San Francisco" },
+   { "zip": "89101", "city": "Las Vegas", "tags": ["home", "work"] }
+  ],
+  "created": "2017-07-10T12:13:47Z",
+  "nothing": null
+ },
+ "hosts": { "example.com": { "port": 8080 } }
+}
+`
+
+func TestParsePath(t *testing.T) {
+	var segments []string
+	var err error
+
+	segments, err = parsePath("user.addresses[0].zip")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"user", "addresses", "0", "zip"}, segments)
+
+	segments, err = parsePath("hosts[example.com].port")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"hosts", "example.com", "port"}, segments)
+
+	segments, err = parsePath("[a][b][0]")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "0"}, segments)
+
+	for _, path := range []string{"", ".a", "a.", "a..b", "a[0", "a[0]b", "a.[0]"} {
+		_, err = parsePath(path)
+		assert.Equal(t, ErrInvalidPath, err, path)
+	}
+}
+
+func TestValueAt(t *testing.T) {
+	var gmap Map
+	var err error
+	var value interface{}
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testPathPayload), &gmap)
+	assert.Nil(t, err)
+
+	value, err = gmap.ValueAt("user.addresses[1].city")
+	assert.Nil(t, err)
+	assert.Equal(t, "Las Vegas", value)
+
+	value, err = gmap.ValueAt("hosts[example.com].port")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 8080, value)
+
+	value, err = gmap.ValueAt("user.phones[0]")
+	assert.Nil(t, value)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.Equal(t, "phones", err.(*PathError).Segment)
+
+	_, err = gmap.ValueAt("user.addresses[5].zip")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.Equal(t, "5", err.(*PathError).Segment)
+
+	_, err = gmap.ValueAt("user.name.first")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, "first", err.(*PathError).Segment)
+
+	_, err = gmap.ValueAt("user.nothing.really")
+	assert.True(t, errors.Is(err, ErrNilValue))
+	assert.Equal(t, "nothing", err.(*PathError).Segment)
+
+	_, err = gmap.ValueAt("user..name")
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+}
+
+func TestValueAtNestedTypes(t *testing.T) {
+	var gmap Map
+	var err error
+	var value int
+
+	gmap = Map{
+		"yaml": map[interface{}]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{"value": 1},
+				Map{"value": 2},
+			},
+		},
+	}
+
+	value, err = gmap.IntAt("yaml.list[0].value", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+
+	value, err = gmap.IntAt("yaml.list[1].value", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func TestTypedAt(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testPathPayload), &gmap)
+	assert.Nil(t, err)
+
+	zip, err := gmap.IntAt("user.addresses[0].zip", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 94105, zip)
+
+	zip, err = gmap.IntAt("user.addresses[1].zip", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 89101, zip)
+
+	zip, err = gmap.IntAt("user.addresses[2].zip", -1)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.Equal(t, -1, zip)
+
+	age, err := gmap.FloatAt("user.age", 0.0)
+	assert.Nil(t, err)
+	assert.Equal(t, 42.0, age)
+
+	name, err := gmap.StringAt("user.name", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "John", name)
+
+	_, err = gmap.BooleanAt("user.addresses", false)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, "user.addresses", err.(*Error).Key)
+
+	tags, err := gmap.StringArrayAt("user.addresses[1].tags", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"home", "work"}, tags)
+
+	address, err := gmap.MapAt("user.addresses[0]", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "San Francisco", address["city"])
+
+	addresses, err := gmap.ArrayAt("user.addresses", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(addresses))
+
+	created, err := gmap.TimeUTCAt("user.created", time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2017, created.Year())
+}
+
+func TestDurationAt(t *testing.T) {
+	gmap := Map{"server": Map{"timeouts": []interface{}{"PT1M", 5}}}
+
+	d, err := gmap.DurationAt("server.timeouts[0]", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, d)
+
+	d, err = gmap.DurationAt("server.timeouts[1]", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 5*time.Second, d)
+
+	_, err = gmap.DurationAt("server.missing", 0)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+}
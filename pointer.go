@@ -0,0 +1,125 @@
+package gmap
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Pointer is a parsed RFC 6901 JSON Pointer, holding its unescaped reference tokens.
+// The empty Pointer refers to the whole document.
+type Pointer []string
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Parses a JSON Pointer such as '/items/3/price'.
+// Reference tokens are unescaped, so '~1' becomes '/' and '~0' becomes '~'.
+func ParsePointer(s string) (Pointer, error) {
+	if s == "" {
+		return Pointer{}, nil
+	}
+
+	if s[0] != '/' {
+		return nil, ErrInvalidPointer
+	}
+
+	tokens := strings.Split(s[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, ErrInvalidPointer
+			}
+		}
+		token = strings.Replace(token, "~1", "/", -1)
+		tokens[i] = strings.Replace(token, "~0", "~", -1)
+	}
+
+	return Pointer(tokens), nil
+}
+
+// Returns the JSON Pointer representation, escaping '~' and '/' in each reference token.
+func (p Pointer) String() string {
+	var sb strings.Builder
+	for _, token := range p {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(token))
+	}
+	return sb.String()
+}
+
+// Retrieves the value referenced by a JSON Pointer.
+// The empty pointer refers to the whole Map.
+// Returns a *PathError if the pointer cannot be resolved.
+func (m Map) GetPointer(ptr string) (interface{}, error) {
+	p, err := ParsePointer(ptr)
+	if err != nil {
+		return nil, &PathError{Path: ptr, Err: err}
+	}
+
+	return lookup(ptr, m, p)
+}
+
+// Retrieves the value referenced by a JSON Pointer as T, converting it the same way as Get,
+// e.g. GetPointerAs[float64](m, "/items/3/price", 0).
+// Returns the default value and a *PathError if the pointer cannot be resolved or the value is nil,
+// or an *Error if the value cannot be converted.
+func GetPointerAs[T any](m Map, ptr string, def T) (T, error) {
+	value, err := m.GetPointer(ptr)
+	if err != nil {
+		return def, err
+	}
+
+	p, _ := ParsePointer(ptr)
+	segment := ""
+	if len(p) > 0 {
+		segment = p[len(p)-1]
+	}
+	if value == nil {
+		return def, &PathError{Path: ptr, Segment: segment, Err: ErrNilValue}
+	}
+
+	v, err := interfaceToType(value, def)
+	return v, newValueError(ptr, reflect.TypeOf(&def).Elem().String(), value, err)
+}
+
+// Returns true if the JSON Pointer references an existing value, even if that value is nil.
+func (m Map) HasPointer(ptr string) bool {
+	_, err := m.GetPointer(ptr)
+	return err == nil
+}
+
+// Sets the value referenced by a JSON Pointer.
+// The parent of the referenced value must already exist.
+// Array elements are replaced, and '-' or the length of the array appends a new element.
+// Returns a *PathError if the pointer cannot be resolved.
+func (m Map) SetPointer(ptr string, value interface{}) error {
+	p, err := ParsePointer(ptr)
+	if err != nil {
+		return &PathError{Path: ptr, Err: err}
+	}
+
+	if len(p) == 0 {
+		return &PathError{Path: ptr, Err: ErrInvalidPointer}
+	}
+
+	_, err = update(ptr, m, p, false, func(container interface{}, segment string) (interface{}, error) {
+		return assign(container, segment, value)
+	})
+	return err
+}
+
+// Deletes the value referenced by a JSON Pointer.
+// Array elements after a deleted element are shifted down.
+// Returns a *PathError if the pointer cannot be resolved.
+func (m Map) DeletePointer(ptr string) error {
+	p, err := ParsePointer(ptr)
+	if err != nil {
+		return &PathError{Path: ptr, Err: err}
+	}
+
+	if len(p) == 0 {
+		return &PathError{Path: ptr, Err: ErrInvalidPointer}
+	}
+
+	_, err = update(ptr, m, p, false, remove)
+	return err
+}
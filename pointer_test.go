@@ -0,0 +1,188 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPointerPayload = `
+{
+ "items": [
+  { "name": "apple", "price": "0.5" },
+  { "name": "pear", "price": 0.75 }
+ ],
+ "a/b": { "m~n": 8 },
+ "": "empty key",
+ "nothing": null
+}
+`
+
+func TestParsePointer(t *testing.T) {
+	var p Pointer
+	var err error
+
+	p, err = ParsePointer("")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(p))
+
+	p, err = ParsePointer("/items/0/price")
+	assert.Nil(t, err)
+	assert.Equal(t, Pointer{"items", "0", "price"}, p)
+
+	p, err = ParsePointer("/a~1b/m~0n")
+	assert.Nil(t, err)
+	assert.Equal(t, Pointer{"a/b", "m~n"}, p)
+	assert.Equal(t, "/a~1b/m~0n", p.String())
+
+	p, err = ParsePointer("/~01")
+	assert.Nil(t, err)
+	assert.Equal(t, Pointer{"~1"}, p)
+	assert.Equal(t, "/~01", p.String())
+
+	for _, s := range []string{"items", "/a~2b", "/a~"} {
+		_, err = ParsePointer(s)
+		assert.Equal(t, ErrInvalidPointer, err, s)
+	}
+}
+
+func TestGetPointer(t *testing.T) {
+	var gmap Map
+	var err error
+	var value interface{}
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testPointerPayload), &gmap)
+	assert.Nil(t, err)
+
+	value, err = gmap.GetPointer("")
+	assert.Nil(t, err)
+	assert.Equal(t, gmap, value)
+
+	value, err = gmap.GetPointer("/items/1/name")
+	assert.Nil(t, err)
+	assert.Equal(t, "pear", value)
+
+	value, err = gmap.GetPointer("/a~1b/m~0n")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 8, value)
+
+	value, err = gmap.GetPointer("/")
+	assert.Nil(t, err)
+	assert.Equal(t, "empty key", value)
+
+	value, err = gmap.GetPointer("/nothing")
+	assert.Nil(t, err)
+	assert.Nil(t, value)
+
+	_, err = gmap.GetPointer("/items/2/name")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.Equal(t, "2", err.(*PathError).Segment)
+
+	assert.True(t, gmap.HasPointer("/items/0"))
+	assert.True(t, gmap.HasPointer("/nothing"))
+	assert.False(t, gmap.HasPointer("/items/0/color"))
+	assert.False(t, gmap.HasPointer("items"))
+}
+
+func TestTypedPointer(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testPointerPayload), &gmap)
+	assert.Nil(t, err)
+
+	price, err := GetPointerAs[float64](gmap, "/items/0/price", 0.0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, price)
+
+	price, err = GetPointerAs[float64](gmap, "/items/1/price", 0.0)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.75, price)
+
+	name, err := GetPointerAs[string](gmap, "/items/1/name", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "pear", name)
+
+	_, err = GetPointerAs[int](gmap, "/nothing", 0)
+	assert.True(t, errors.Is(err, ErrNilValue))
+	assert.Equal(t, "nothing", err.(*PathError).Segment)
+
+	_, err = GetPointerAs[Map](gmap, "/items/1/name", nil)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, "/items/1/name", err.(*Error).Key)
+
+	_, err = GetPointerAs[int](gmap, "/items/5/price", 0)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	// the path getters do not read JSON Pointers, so keys starting with '/' still work
+	gmap = Map{"/a": 1}
+	value, err := gmap.IntAt("/a", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, value)
+}
+
+func TestSetPointer(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testPointerPayload), &gmap)
+	assert.Nil(t, err)
+
+	err = gmap.SetPointer("/items/0/price", 0.6)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.6, gmap["items"].([]interface{})[0].(map[string]interface{})["price"])
+
+	err = gmap.SetPointer("/items/-", Map{"name": "plum"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(gmap["items"].([]interface{})))
+
+	err = gmap.SetPointer("/items/3", "fig")
+	assert.Nil(t, err)
+	assert.Equal(t, "fig", gmap["items"].([]interface{})[3])
+
+	err = gmap.SetPointer("/items/9", "kiwi")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	err = gmap.SetPointer("/missing/key", 1)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.Equal(t, "missing", err.(*PathError).Segment)
+
+	err = gmap.SetPointer("", 1)
+	assert.True(t, errors.Is(err, ErrInvalidPointer))
+
+	yaml := Map{"root": map[interface{}]interface{}{1: "one"}}
+	err = yaml.SetPointer("/root/1", "uno")
+	assert.Nil(t, err)
+	assert.Equal(t, "uno", yaml["root"].(map[interface{}]interface{})[1])
+}
+
+func TestDeletePointer(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{}
+	err = json.Unmarshal([]byte(testPointerPayload), &gmap)
+	assert.Nil(t, err)
+
+	err = gmap.DeletePointer("/items/0")
+	assert.Nil(t, err)
+	items := gmap["items"].([]interface{})
+	assert.Equal(t, 1, len(items))
+	assert.Equal(t, "pear", items[0].(map[string]interface{})["name"])
+
+	err = gmap.DeletePointer("/a~1b/m~0n")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(gmap["a/b"].(map[string]interface{})))
+
+	err = gmap.DeletePointer("/a~1b/m~0n")
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	err = gmap.DeletePointer("/nothing")
+	assert.Nil(t, err)
+	assert.False(t, gmap.HasPointer("/nothing"))
+}
@@ -0,0 +1,207 @@
+package gmap
+
+import (
+	"errors"
+	"math/big"
+	"time"
+)
+
+// ReaderError lists every key that a Reader could not read.
+// Each entry is an *Error whose Key is the key.
+type ReaderError struct {
+	Errors []error
+}
+
+func (e *ReaderError) Error() string {
+	return formatErrors("gmap read failed", e.Errors)
+}
+
+// Unwrap returns the errors of every failed key, so errors.Is matches any of them.
+func (e *ReaderError) Unwrap() []error {
+	return e.Errors
+}
+
+// Reader reads many values from a Map without checking an error after each one.
+// Its getters return the value, or the default if the value is missing or cannot be converted,
+// and remember the failures, which are reported together by Err.
+//
+// Keys are optional unless marked with Require, so a missing optional key is not an error,
+// while a value of the wrong type always is.
+//
+//	r := gmap.NewReader(m).Require("host", "port")
+//	host := r.String("host", "")
+//	port := r.Int("port", 0)
+//	timeout := r.Duration("timeout", 30*time.Second)
+//	if err := r.Err(); err != nil {
+//		return err
+//	}
+type Reader struct {
+	m        Map
+	required map[string]bool
+	failed   map[string]bool
+	errs     []error
+}
+
+// Returns a new Reader that reads from m.
+func NewReader(m Map) *Reader {
+	return &Reader{m: m, required: map[string]bool{}, failed: map[string]bool{}}
+}
+
+// Marks keys as required, recording ErrKeyDoesNotExist or ErrNilValue for each one that is missing or nil.
+// Returns the Reader, so it can be chained with NewReader.
+func (r *Reader) Require(keys ...string) *Reader {
+	for _, key := range keys {
+		r.required[key] = true
+
+		value, ok := r.m[key]
+		if !ok {
+			r.fail(key, newKeyError(key, "", ErrKeyDoesNotExist))
+		} else if value == nil {
+			r.fail(key, newKeyError(key, "", ErrNilValue))
+		}
+	}
+	return r
+}
+
+// Reports whether key has been marked with Require.
+func (r *Reader) Required(key string) bool {
+	return r.required[key]
+}
+
+// Records err for key, once per key.
+func (r *Reader) fail(key string, err error) {
+	if r.failed[key] {
+		return
+	}
+	r.failed[key] = true
+	r.errs = append(r.errs, err)
+}
+
+// Records the error of a getter, and returns def instead of v if there was one.
+// Missing and nil values are reported by Require instead.
+func read[T any](r *Reader, key string, v T, def T, err error) T {
+	if err == nil {
+		return v
+	}
+	if !errors.Is(err, ErrKeyDoesNotExist) && !errors.Is(err, ErrNilValue) {
+		r.fail(key, err)
+	}
+	return def
+}
+
+// Returns nil if every value was read, or a *ReaderError listing every key that was missing
+// or could not be converted, in the order the failures were found.
+func (r *Reader) Err() error {
+	if len(r.errs) == 0 {
+		return nil
+	}
+	return &ReaderError{Errors: append([]error(nil), r.errs...)}
+}
+
+// Reads a Map. See Map.Map.
+func (r *Reader) Map(key string, def Map) Map {
+	v, err := r.m.Map(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an array of interface{}. See Map.Array.
+func (r *Reader) Array(key string, def []interface{}) []interface{} {
+	v, err := r.m.Array(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an int. See Map.Int.
+func (r *Reader) Int(key string, def int) int {
+	v, err := r.m.Int(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an int64. See Map.Int64.
+func (r *Reader) Int64(key string, def int64) int64 {
+	v, err := r.m.Int64(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a uint64. See Map.Uint64.
+func (r *Reader) Uint64(key string, def uint64) uint64 {
+	v, err := r.m.Uint64(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an int32. See Map.Int32.
+func (r *Reader) Int32(key string, def int32) int32 {
+	v, err := r.m.Int32(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a uint32. See Map.Uint32.
+func (r *Reader) Uint32(key string, def uint32) uint32 {
+	v, err := r.m.Uint32(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a float64. See Map.Float.
+func (r *Reader) Float(key string, def float64) float64 {
+	v, err := r.m.Float(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a *big.Int. See Map.BigInt.
+func (r *Reader) BigInt(key string, def *big.Int) *big.Int {
+	v, err := r.m.BigInt(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a Decimal. See Map.Decimal.
+func (r *Reader) Decimal(key string, def Decimal) Decimal {
+	v, err := r.m.Decimal(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a string. See Map.String.
+func (r *Reader) String(key string, def string) string {
+	v, err := r.m.String(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a bool. See Map.Boolean.
+func (r *Reader) Boolean(key string, def bool) bool {
+	v, err := r.m.Boolean(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an array of strings. See Map.StringArray.
+func (r *Reader) StringArray(key string, def []string) []string {
+	v, err := r.m.StringArray(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an array of float64. See Map.FloatArray.
+func (r *Reader) FloatArray(key string, def []float64) []float64 {
+	v, err := r.m.FloatArray(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads an array of int. See Map.IntArray.
+func (r *Reader) IntArray(key string, def []int) []int {
+	v, err := r.m.IntArray(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a time.Time. See Map.Time.
+func (r *Reader) Time(key string, def time.Time) time.Time {
+	v, err := r.m.Time(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a time.Duration. See Map.Duration.
+func (r *Reader) Duration(key string, def time.Duration) time.Duration {
+	v, err := r.m.Duration(key, def)
+	return read(r, key, v, def, err)
+}
+
+// Reads a value of any type from a Reader, the same way as Get.
+func Read[T any](r *Reader, key string, def T) T {
+	v, err := Get(r.m, key, def)
+	return read(r, key, v, def, err)
+}
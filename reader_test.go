@@ -0,0 +1,61 @@
+package gmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReader(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"host":    "example.com",
+		"port":    "8080",
+		"debug":   "true",
+		"timeout": "1m",
+		"retries": "many",
+		"tags":    []interface{}{"a", "b"},
+		"limits":  Map{"rate": 10},
+		"owner":   nil,
+	}
+
+	r := NewReader(gmap).Require("host", "port", "owner", "region")
+	assert.Equal(t, "example.com", r.String("host", ""))
+	assert.Equal(t, 8080, r.Int("port", 0))
+	assert.Equal(t, true, r.Boolean("debug", false))
+	assert.Equal(t, time.Minute, r.Duration("timeout", 0))
+	assert.Equal(t, []string{"a", "b"}, r.StringArray("tags", nil))
+	assert.Equal(t, Map{"rate": 10}, r.Map("limits", nil))
+	assert.Equal(t, int8(3), Read[int8](r, "workers", 3))
+	assert.Equal(t, 3, r.Int("retries", 3))
+	assert.Equal(t, "", r.String("region", ""))
+	assert.True(t, r.Required("host"))
+	assert.False(t, r.Required("debug"))
+
+	err := r.Err()
+	assert.NotNil(t, err)
+	errs := err.(*ReaderError).Errors
+	assert.Len(t, errs, 3)
+	assert.Equal(t, "owner", errs[0].(*Error).Key)
+	assert.True(t, errors.Is(errs[0], ErrNilValue))
+	assert.Equal(t, `gmap key "region": gmap key does not exist`, errs[1].Error())
+	assert.True(t, errors.Is(errs[1], ErrKeyDoesNotExist))
+	assert.Equal(t, "retries", errs[2].(*Error).Key)
+	assert.Equal(t, "int", errs[2].(*Error).Type)
+	assert.Equal(t, `gmap key "retries": cannot convert string "many" to int: strconv.Atoi: parsing "many": invalid syntax`, errs[2].Error())
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	// each key is reported once
+	r.Int("retries", 0)
+	assert.Len(t, r.Err().(*ReaderError).Errors, 3)
+
+	assert.NotContains(t, Redact(err).Error(), "many")
+
+	r = NewReader(gmap).Require("host")
+	r.String("host", "")
+	r.Float("missing", 1.5)
+	assert.Nil(t, r.Err())
+}
@@ -0,0 +1,241 @@
+package gmap
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxBodySize is the body size limit used by FromRequest when none is given, the same as http.Request.ParseForm.
+const DefaultMaxBodySize = 10 << 20
+
+// DefaultMaxMemory is the number of bytes of multipart file parts FromRequest keeps in memory when none is given.
+const DefaultMaxMemory = 32 << 20
+
+// Precedence determines which values FromRequest keeps when the query string and the body both have a key.
+type Precedence int
+
+const (
+	// BodyFirst keeps the values from the body. This is the default.
+	BodyFirst Precedence = iota
+	// QueryFirst keeps the values from the query string.
+	QueryFirst
+	// BodyOnly ignores the query string.
+	BodyOnly
+)
+
+// RequestOptions configures how FromRequest reads an *http.Request.
+type RequestOptions struct {
+	// Precedence determines which values are kept when the query string and the body both have a key.
+	Precedence Precedence
+	// MaxBodySize limits the size of the body in bytes. Defaults to DefaultMaxBodySize.
+	MaxBodySize int64
+	// MaxMemory is the number of bytes of multipart file parts kept in memory. Defaults to DefaultMaxMemory.
+	MaxMemory int64
+	// Form configures how the query string and form bodies are read.
+	Form FromUrlValuesOptions
+	// Strict rejects conflicting form keys, including uploaded files, the same way as FromUrlValuesStrict.
+	Strict bool
+}
+
+// Reads at most limit bytes, remembering whether the body was longer than that.
+type limitedBody struct {
+	r        io.Reader
+	n        int64
+	exceeded bool
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		// read one more byte to tell a body of exactly limit bytes from a longer one
+		var b [1]byte
+		if n, _ := l.r.Read(b[:]); n > 0 {
+			l.exceeded = true
+			return 0, ErrBodyTooLarge
+		}
+		return 0, io.EOF
+	}
+
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// Reads url.Values, and any uploaded files, into a new Map, as configured by opts.
+func (o RequestOptions) values(values url.Values, files map[string][]*multipart.FileHeader) (Map, error) {
+	var more func(Map) []error
+	if len(files) > 0 {
+		more = func(mp Map) []error {
+			return addFormFiles(mp, files)
+		}
+	}
+
+	mp := Map{}
+	return mp, mp.fromForm(values, o.Form, o.Strict, len(files), more)
+}
+
+// Converts an uploaded file to a Map describing it.
+// The *multipart.FileHeader is kept under 'header', so that the file can be opened.
+func fileMap(fh *multipart.FileHeader) Map {
+	return Map{
+		"filename":     fh.Filename,
+		"size":         fh.Size,
+		"content_type": fh.Header.Get("Content-Type"),
+		"header":       fh,
+	}
+}
+
+// Adds the uploaded files to mp under their form keys, before nested Maps are turned into arrays,
+// so that 'docs[0][file]' joins the other fields of 'docs[0]'.
+// Keys ending in '[]', or sent with more than one file, become arrays of file Maps.
+// Returns a *PathError wrapping ErrKeyConflict for every file that replaced an existing value.
+func addFormFiles(mp Map, files map[string][]*multipart.FileHeader) []error {
+	keys := make([]string, 0, len(files))
+	for k := range files {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, k := range keys {
+		subkeys := splitFormKey(k)
+		if len(subkeys) == 0 {
+			subkeys = []string{k}
+		}
+
+		appending := len(subkeys) > 1 && subkeys[len(subkeys)-1] == ""
+		if appending {
+			subkeys = subkeys[:len(subkeys)-1]
+		}
+
+		var value interface{}
+		if appending || len(files[k]) > 1 {
+			arr := make([]interface{}, len(files[k]))
+			for i, fh := range files[k] {
+				arr[i] = fileMap(fh)
+			}
+			value = arr
+		} else if len(files[k]) == 1 {
+			value = fileMap(files[k][0])
+		}
+
+		lastIndex := len(subkeys) - 1
+		submap, err := formMap(k, mp, subkeys[:lastIndex])
+		if _, exists := submap[subkeys[lastIndex]]; exists && err == nil {
+			err = &PathError{Path: k, Segment: subkeys[lastIndex], Err: ErrKeyConflict}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		submap[subkeys[lastIndex]] = value
+	}
+	return errs
+}
+
+// Reads the body of r into a new Map, based on its Content-Type.
+// Requests without a Content-Type are treated as having no body.
+func (o RequestOptions) body(r *http.Request) (Map, error) {
+	contentType := r.Header.Get("Content-Type")
+	if r.Body == nil || r.Body == http.NoBody || contentType == "" {
+		return Map{}, nil
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedContentType
+	}
+
+	limit := o.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+	body := &limitedBody{r: r.Body, n: limit}
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		values, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, err
+		}
+		return o.values(values, nil)
+
+	case mediaType == "multipart/form-data":
+		maxMemory := o.MaxMemory
+		if maxMemory <= 0 {
+			maxMemory = DefaultMaxMemory
+		}
+		form, err := multipart.NewReader(body, params["boundary"]).ReadForm(maxMemory)
+		if body.exceeded {
+			return nil, ErrBodyTooLarge
+		}
+		if err != nil {
+			return nil, err
+		}
+		r.MultipartForm = form
+
+		return o.values(form.Value, form.File)
+
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		mp := Map{}
+		dec := json.NewDecoder(body)
+		dec.UseNumber()
+		err := dec.Decode(&mp)
+		if err == nil && dec.Decode(&json.RawMessage{}) != io.EOF {
+			err = ErrTrailingData
+		}
+		if body.exceeded {
+			return nil, ErrBodyTooLarge
+		}
+		if err == io.EOF {
+			return Map{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return mp, nil
+	}
+
+	return nil, ErrUnsupportedContentType
+}
+
+// Creates a new Map from an *http.Request, reading both the query string and the body.
+// The body is read based on its Content-Type, which can be 'application/x-www-form-urlencoded',
+// 'multipart/form-data' or 'application/json'. Uploaded files are described by Maps with the keys
+// 'filename', 'size', 'content_type' and 'header', the last being the *multipart.FileHeader.
+// JSON numbers are kept as json.Number, so large integers such as IDs are not rounded to float64,
+// and a JSON body must hold a single object, or ErrTrailingData is returned.
+// Keys found in both the query string and the body are deeply merged, keeping the values given by opts.Precedence.
+// Returns ErrBodyTooLarge if the body is longer than opts.MaxBodySize,
+// or ErrUnsupportedContentType if the body has any other Content-Type.
+func FromRequest(r *http.Request, opts RequestOptions) (Map, error) {
+	body, err := opts.body(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Precedence == BodyOnly || r.URL == nil {
+		return body, nil
+	}
+
+	query, err := opts.values(r.URL.Query(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Precedence == QueryFirst {
+		return body.DeepMerge(query), nil
+	}
+	return query.DeepMerge(body), nil
+}
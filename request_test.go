@@ -0,0 +1,174 @@
+package gmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromRequestForm(t *testing.T) {
+	r := httptest.NewRequest("POST", "/users?page=2&name=query", strings.NewReader("name=John&tags[]=a&tags[]=b"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	mp, err := FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, Map{
+		"page": "2",
+		"name": "John",
+		"tags": []interface{}{"a", "b"},
+	}, mp)
+
+	r = httptest.NewRequest("POST", "/users?page=2&name=query", strings.NewReader("name=John"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	mp, err = FromRequest(r, RequestOptions{Precedence: QueryFirst})
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"page": "2", "name": "query"}, mp)
+
+	r = httptest.NewRequest("POST", "/users?page=2", strings.NewReader("name=John"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	mp, err = FromRequest(r, RequestOptions{Precedence: BodyOnly})
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"name": "John"}, mp)
+
+	r = httptest.NewRequest("GET", "/users?user[name]=John", nil)
+	mp, err = FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"user": Map{"name": "John"}}, mp)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader("foo=1&foo[bar]=2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = FromRequest(r, RequestOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrKeyConflict))
+}
+
+func TestFromRequestJSON(t *testing.T) {
+	r := httptest.NewRequest("POST", "/users?page=2", strings.NewReader(`{"user": {"name": "John"}, "page": 3}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	mp, err := FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+	name, _ := mp.StringAt("user.name", "")
+	assert.Equal(t, "John", name)
+	assert.Equal(t, json.Number("3"), mp["page"])
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`[1, 2]`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = FromRequest(r, RequestOptions{})
+	assert.NotNil(t, err)
+
+	// large integers are not rounded
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"id": 9007199254740993}`))
+	r.Header.Set("Content-Type", "application/json")
+	mp, err = FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+	id, err := mp.Int64("id", 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(9007199254740993), id)
+
+	for _, body := range []string{`{"a": 1} {"b": 2}`, `{"a": 1}]`, `{"a": 1} x`} {
+		r = httptest.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		mp, err = FromRequest(r, RequestOptions{})
+		assert.Equal(t, ErrTrailingData, err)
+		assert.Nil(t, mp)
+	}
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader("{\"a\": 1}\n"))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+}
+
+func TestFromRequestMultipart(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	w.WriteField("user[name]", "John")
+	fw, _ := w.CreateFormFile("avatar", "me.png")
+	fw.Write([]byte("png"))
+	fw, _ = w.CreateFormFile("docs[]", "a.txt")
+	fw.Write([]byte("hello"))
+	w.Close()
+
+	r := httptest.NewRequest("POST", "/upload", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	mp, err := FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+	name, _ := mp.StringAt("user.name", "")
+	assert.Equal(t, "John", name)
+
+	filename, _ := mp.StringAt("avatar.filename", "")
+	assert.Equal(t, "me.png", filename)
+	assert.Equal(t, "application/octet-stream", mp["avatar"].(Map)["content_type"])
+
+	size, _ := mp.IntAt("docs[0].size", 0)
+	assert.Equal(t, 5, size)
+	fh, ok := mp["docs"].([]interface{})[0].(Map)["header"].(*multipart.FileHeader)
+	assert.True(t, ok)
+	assert.Equal(t, "a.txt", fh.Filename)
+}
+
+func TestFromRequestMultipartRows(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	w.WriteField("docs[0][title]", "a")
+	fw, _ := w.CreateFormFile("docs[0][file]", "a.txt")
+	fw.Write([]byte("hello"))
+	fw, _ = w.CreateFormFile("scans[0][file]", "scan.pdf")
+	fw.Write([]byte("pdf"))
+	w.WriteField("note", "text")
+	fw, _ = w.CreateFormFile("note", "note.txt")
+	fw.Write([]byte("file"))
+	w.Close()
+	body := buf.Bytes()
+
+	r := httptest.NewRequest("POST", "/upload", bytes.NewReader(body))
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	mp, err := FromRequest(r, RequestOptions{})
+	assert.Nil(t, err)
+	title, _ := mp.StringAt("docs[0].title", "")
+	assert.Equal(t, "a", title)
+	filename, _ := mp.StringAt("docs[0].file.filename", "")
+	assert.Equal(t, "a.txt", filename)
+	filename, _ = mp.StringAt("scans[0].file.filename", "")
+	assert.Equal(t, "scan.pdf", filename)
+	filename, _ = mp.StringAt("note.filename", "")
+	assert.Equal(t, "note.txt", filename)
+
+	r = httptest.NewRequest("POST", "/upload", bytes.NewReader(body))
+	r.Header.Set("Content-Type", w.FormDataContentType())
+
+	_, err = FromRequest(r, RequestOptions{Strict: true})
+	assert.True(t, errors.Is(err, ErrKeyConflict))
+	assert.Len(t, err.(*FormError).Errors, 1)
+	assert.Equal(t, "note", err.(*FormError).Errors[0].(*PathError).Path)
+}
+
+func TestFromRequestLimits(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader("name=John"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err := FromRequest(r, RequestOptions{MaxBodySize: 4})
+	assert.Equal(t, ErrBodyTooLarge, err)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader("name=John"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = FromRequest(r, RequestOptions{MaxBodySize: 9})
+	assert.Nil(t, err)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader(`{"name": "John"}`))
+	r.Header.Set("Content-Type", "application/json")
+	_, err = FromRequest(r, RequestOptions{MaxBodySize: 8})
+	assert.Equal(t, ErrBodyTooLarge, err)
+
+	r = httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	r.Header.Set("Content-Type", "text/plain")
+	_, err = FromRequest(r, RequestOptions{})
+	assert.Equal(t, ErrUnsupportedContentType, err)
+}
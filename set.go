@@ -0,0 +1,133 @@
+package gmap
+
+// Sets the value at the given path, e.g. 'user.addresses[0].zip'.
+// Missing or nil intermediate values are created, as a Map for keys or an []interface{} for numeric segments.
+// Arrays grow as needed and are padded with nil values, by at most 10000 at a time.
+// Returns a *PathError if an intermediate value is neither a Map nor an array, or wrapping ErrIndexOutOfRange
+// if an index is too far past the end of its array.
+func (m Map) Set(path string, value interface{}) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+
+	_, err = update(path, m, segments, true, func(container interface{}, segment string) (interface{}, error) {
+		return put(container, segment, value)
+	})
+	return err
+}
+
+// Sets the value at the given path only if it does not exist or is nil.
+// Creates intermediate values the same way as Set.
+// Returns the value stored at the path afterwards.
+func (m Map) SetDefault(path string, value interface{}) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, &PathError{Path: path, Err: err}
+	}
+
+	result := value
+	_, err = update(path, m, segments, true, func(container interface{}, segment string) (interface{}, error) {
+		if existing, err := child(container, segment); err == nil && existing != nil {
+			result = existing
+			return container, nil
+		}
+		return put(container, segment, value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Deletes the value at the given path.
+// Array elements after a deleted element are shifted down.
+// Returns a *PathError if the path cannot be resolved.
+func (m Map) Delete(path string) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return &PathError{Path: path, Err: err}
+	}
+
+	_, err = update(path, m, segments, false, remove)
+	return err
+}
+
+// Moves the value at one path to another, creating intermediate values at the destination the same way as Set.
+// The Map is left unchanged if the value cannot be moved.
+// Returns a *PathError if the source does not exist or the destination is inside the source.
+func (m Map) Move(from, to string) error {
+	fromSegments, err := parsePath(from)
+	if err != nil {
+		return &PathError{Path: from, Err: err}
+	}
+
+	toSegments, err := parsePath(to)
+	if err != nil {
+		return &PathError{Path: to, Err: err}
+	}
+
+	value, err := lookup(from, m, fromSegments)
+	if err != nil {
+		return err
+	}
+
+	if hasPrefix(toSegments, fromSegments) {
+		if len(toSegments) == len(fromSegments) {
+			return nil
+		}
+		return &PathError{Path: to, Segment: fromSegments[len(fromSegments)-1], Err: ErrInvalidPath}
+	}
+
+	_, err = update(from, m, fromSegments, false, remove)
+	if err != nil {
+		return err
+	}
+
+	_, err = update(to, m, toSegments, true, func(container interface{}, segment string) (interface{}, error) {
+		return put(container, segment, value)
+	})
+	if err != nil {
+		// put the value back where it was. This cannot fail: update attaches new containers to their parents
+		// only once the value has been set, so a failed destination changes nothing, and the source path
+		// still leads to the container the value was just removed from.
+		update(from, m, fromSegments, false, func(container interface{}, segment string) (interface{}, error) {
+			return insert(container, segment, value)
+		})
+		return err
+	}
+
+	return nil
+}
+
+// Copies the value at one path to another, creating intermediate values at the destination the same way as Set.
+// Nested Maps and arrays are copied, so the two paths do not share them.
+// Returns a *PathError if the source does not exist.
+func (m Map) Copy(from, to string) error {
+	fromSegments, err := parsePath(from)
+	if err != nil {
+		return &PathError{Path: from, Err: err}
+	}
+
+	value, err := lookup(from, m, fromSegments)
+	if err != nil {
+		return err
+	}
+
+	return m.Set(to, deepCopy(value))
+}
+
+// Returns true if segments starts with all of prefix.
+func hasPrefix(segments, prefix []string) bool {
+	if len(segments) < len(prefix) {
+		return false
+	}
+
+	for i := range prefix {
+		if segments[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,191 @@
+package gmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"name": "John", "nothing": nil}
+
+	err = gmap.Set("user.address.zip", 94105)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"address": Map{"zip": 94105}}, gmap["user"])
+
+	err = gmap.Set("user.tags[2]", "work")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{nil, nil, "work"}, gmap["user"].(Map)["tags"])
+
+	err = gmap.Set("user.tags[0]", "home")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"home", nil, "work"}, gmap["user"].(Map)["tags"])
+
+	err = gmap.Set("rows.0.name", "first")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{Map{"name": "first"}}, gmap["rows"])
+
+	err = gmap.Set("rows.1.name", "second")
+	assert.Nil(t, err)
+	name, _ := gmap.StringAt("rows[1].name", "")
+	assert.Equal(t, "second", name)
+
+	err = gmap.Set("nothing.here", true)
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"here": true}, gmap["nothing"])
+
+	err = gmap.Set("name.first", "John")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, "first", err.(*PathError).Segment)
+	assert.Equal(t, "John", gmap["name"])
+
+	err = gmap.Set("rows.name", "bad")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+
+	err = gmap.Set("a..b", 1)
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+
+	err = gmap.Set("huge[4611686018427387903]", 1)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.Equal(t, "4611686018427387903", err.(*PathError).Segment)
+	_, ok := gmap["huge"]
+	assert.False(t, ok)
+
+	err = gmap.Set("huge[20000].name", 1)
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+
+	err = gmap.Set("padded[10000]", 1)
+	assert.Nil(t, err)
+	assert.Len(t, gmap["padded"], 10001)
+}
+
+func TestSetNestedTypes(t *testing.T) {
+	var gmap Map
+	var err error
+
+	inner := map[string]interface{}{}
+	yaml := map[interface{}]interface{}{"inner": inner}
+	gmap = Map{"yaml": yaml}
+
+	err = gmap.Set("yaml.inner.value", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inner["value"])
+
+	err = gmap.Set("yaml.other", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, yaml["other"])
+}
+
+func TestSetDefault(t *testing.T) {
+	var gmap Map
+	var err error
+	var value interface{}
+
+	gmap = Map{"config": Map{"timeout": 30, "retries": nil}}
+
+	value, err = gmap.SetDefault("config.timeout", 60)
+	assert.Nil(t, err)
+	assert.Equal(t, 30, value)
+	assert.Equal(t, 30, gmap["config"].(Map)["timeout"])
+
+	value, err = gmap.SetDefault("config.retries", 3)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, 3, gmap["config"].(Map)["retries"])
+
+	value, err = gmap.SetDefault("config.log.level", "info")
+	assert.Nil(t, err)
+	assert.Equal(t, "info", value)
+	assert.Equal(t, Map{"level": "info"}, gmap["config"].(Map)["log"])
+
+	_, err = gmap.SetDefault("config.timeout.seconds", 1)
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+}
+
+func TestDelete(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"user": Map{"name": "John", "tags": []interface{}{"a", "b", "c"}}}
+
+	err = gmap.Delete("user.tags[1]")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "c"}, gmap["user"].(Map)["tags"])
+
+	err = gmap.Delete("user.name")
+	assert.Nil(t, err)
+	_, ok := gmap["user"].(Map)["name"]
+	assert.False(t, ok)
+
+	err = gmap.Delete("user.name")
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	err = gmap.Delete("user.tags[5]")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+}
+
+func TestMove(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"old": Map{"name": "John"}, "list": []interface{}{"a", "b"}, "scalar": 1}
+
+	err = gmap.Move("old.name", "new.name")
+	assert.Nil(t, err)
+	assert.Equal(t, Map{}, gmap["old"])
+	assert.Equal(t, Map{"name": "John"}, gmap["new"])
+
+	err = gmap.Move("list[0]", "first")
+	assert.Nil(t, err)
+	assert.Equal(t, "a", gmap["first"])
+	assert.Equal(t, []interface{}{"b"}, gmap["list"])
+
+	err = gmap.Move("new", "new.inner")
+	assert.True(t, errors.Is(err, ErrInvalidPath))
+	assert.Equal(t, Map{"name": "John"}, gmap["new"])
+
+	err = gmap.Move("new", "new")
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"name": "John"}, gmap["new"])
+
+	err = gmap.Move("missing", "elsewhere")
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+
+	// a failed destination leaves the source untouched
+	err = gmap.Move("list[0]", "scalar.b")
+	assert.True(t, errors.Is(err, ErrTypeMismatch))
+	assert.Equal(t, []interface{}{"b"}, gmap["list"])
+	assert.Equal(t, 1, gmap["scalar"])
+
+	// so does a destination that fails after creating containers
+	err = gmap.Move("list[0]", "far.away[20000]")
+	assert.True(t, errors.Is(err, ErrIndexOutOfRange))
+	assert.Equal(t, []interface{}{"b"}, gmap["list"])
+	assert.Nil(t, gmap["far"])
+}
+
+func TestCopy(t *testing.T) {
+	var gmap Map
+	var err error
+
+	gmap = Map{"defaults": Map{"tags": []interface{}{"a"}}}
+
+	err = gmap.Copy("defaults", "user.settings")
+	assert.Nil(t, err)
+
+	err = gmap.Set("user.settings.tags[1]", "b")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, gmap["user"].(Map)["settings"].(Map)["tags"])
+	assert.Equal(t, []interface{}{"a"}, gmap["defaults"].(Map)["tags"])
+
+	err = gmap.Copy("defaults", "defaults.self")
+	assert.Nil(t, err)
+	assert.Equal(t, Map{"tags": []interface{}{"a"}}, gmap["defaults"].(Map)["self"])
+
+	err = gmap.Copy("missing", "elsewhere")
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+}
@@ -0,0 +1,190 @@
+package gmap
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EpochUnit determines how a TimeParser reads Unix epoch numbers, such as 1499688827.
+type EpochUnit int
+
+const (
+	// EpochNone rejects numbers, and reads strings of digits only with Layouts. This is the default.
+	EpochNone EpochUnit = iota
+	// EpochSeconds reads numbers as seconds since the Unix epoch.
+	EpochSeconds
+	// EpochMilliseconds reads numbers as milliseconds since the Unix epoch.
+	EpochMilliseconds
+	// EpochMicroseconds reads numbers as microseconds since the Unix epoch.
+	EpochMicroseconds
+	// EpochNanoseconds reads numbers as nanoseconds since the Unix epoch.
+	EpochNanoseconds
+	// EpochAuto guesses the unit from the size of the number, e.g. 1499688827 as seconds
+	// and 1499688827123 as milliseconds. It works for times between 1973 and 5138.
+	EpochAuto
+)
+
+// TimeParseFunc parses a string to time.Time. It returns an error if it does not recognize the string.
+type TimeParseFunc func(value string) (time.Time, error)
+
+// TimeParser converts strings and numbers to time.Time.
+// Strings are given to each of Parsers, then parsed with each of Layouts, and finally read as epoch numbers
+// if Epoch is set.
+type TimeParser struct {
+	// Layouts are tried in order, as given to time.Parse.
+	Layouts []string
+	// Location is used for layouts without a time zone, such as TimeFormatDate. Defaults to UTC.
+	// Layouts ending in a literal 'Z', such as TimeFormatISO8601, are always read as UTC.
+	Location *time.Location
+	// Epoch determines how numbers, and strings of digits, are read. Defaults to EpochNone,
+	// so epochs are only read when a unit is set, e.g. DefaultTimeParser.Epoch = EpochSeconds.
+	Epoch EpochUnit
+	// Parsers are tried in order before Layouts.
+	Parsers []TimeParseFunc
+}
+
+// DefaultTimeLayouts are the layouts recognized by the Time getters, in the order they are tried.
+// Set DefaultTimeParser.Layouts to change them.
+var DefaultTimeLayouts = []string{
+	TimeFormatISO8601,
+	TimeFormatRFC3339Nano,
+	TimeFormatRFC1123,
+	TimeFormatCommonLog,
+	TimeFormatRubyOffset,
+	TimeFormatRuby,
+	TimeFormatGo,
+	TimeFormatDate,
+}
+
+// DefaultTimeParser is used by the Time getters, Decode and Get[time.Time].
+// Register custom parsers with RegisterTimeParser, before the Maps are read.
+var DefaultTimeParser = &TimeParser{Layouts: DefaultTimeLayouts}
+
+// Adds fn to the parsers of DefaultTimeParser, to be tried before its layouts.
+// It is not safe to call while Maps are being read by other goroutines.
+func RegisterTimeParser(fn TimeParseFunc) {
+	DefaultTimeParser.Parsers = append(DefaultTimeParser.Parsers, fn)
+}
+
+// TimeParseError lists the layouts that were tried when a string could not be parsed to time.Time.
+type TimeParseError struct {
+	Value   string
+	Layouts []string
+}
+
+func (e *TimeParseError) Error() string {
+	return "gmap cannot parse " + strconv.Quote(e.Value) + " as time, tried layouts: " + strings.Join(e.Layouts, ", ")
+}
+
+// Unwrap returns ErrInvalidTime, so errors.Is matches it.
+func (e *TimeParseError) Unwrap() error {
+	return ErrInvalidTime
+}
+
+// Returns the location used for times parsed with layout.
+// Layouts ending in a literal 'Z', such as TimeFormatISO8601, mark UTC times, so they ignore p.Location.
+// Layouts with a numeric offset use the offset in the string either way.
+func (p *TimeParser) location(layout string) *time.Location {
+	if p.Location == nil || strings.HasSuffix(layout, "Z") {
+		return time.UTC
+	}
+	return p.Location
+}
+
+// Converts an epoch number, in the unit given by p.Epoch, to time.Time in UTC.
+func (p *TimeParser) epoch(n *big.Float) (time.Time, error) {
+	unit := p.Epoch
+	if unit == EpochAuto {
+		abs := new(big.Float).Abs(n)
+		switch {
+		case abs.Cmp(big.NewFloat(1e11)) < 0:
+			unit = EpochSeconds
+		case abs.Cmp(big.NewFloat(1e14)) < 0:
+			unit = EpochMilliseconds
+		case abs.Cmp(big.NewFloat(1e17)) < 0:
+			unit = EpochMicroseconds
+		default:
+			unit = EpochNanoseconds
+		}
+	}
+
+	var perSecond int64
+	switch unit {
+	case EpochSeconds:
+		perSecond = 1e0
+	case EpochMilliseconds:
+		perSecond = 1e3
+	case EpochMicroseconds:
+		perSecond = 1e6
+	case EpochNanoseconds:
+		perSecond = 1e9
+	default:
+		return time.Time{}, ErrTypeMismatch
+	}
+
+	// split into whole seconds and nanoseconds, so no precision is lost to float64
+	nanos, _ := new(big.Float).SetPrec(256).Mul(n, big.NewFloat(float64(1e9/perSecond))).Int(nil)
+	sec, nsec := new(big.Int).QuoRem(nanos, big.NewInt(1e9), new(big.Int))
+	if !sec.IsInt64() {
+		return time.Time{}, ErrOverflow
+	}
+	return time.Unix(sec.Int64(), nsec.Int64()).UTC(), nil
+}
+
+// Parses v to time.Time, which can be a time.Time, a string, or an epoch number if Epoch is set.
+// Epoch numbers, including strings of digits such as "1499688827", give times in UTC.
+// Returns ErrTypeMismatch for numbers if Epoch is EpochNone.
+// Returns a *TimeParseError if a string is not recognized.
+func (p *TimeParser) Parse(v interface{}) (time.Time, error) {
+	switch v.(type) {
+	case time.Time:
+		return v.(time.Time), nil
+
+	case string:
+		s := v.(string)
+		for _, fn := range p.Parsers {
+			if t, err := fn(s); err == nil {
+				return t, nil
+			}
+		}
+
+		for _, layout := range p.Layouts {
+			if t, err := time.ParseInLocation(layout, s, p.location(layout)); err == nil {
+				return t, nil
+			}
+		}
+
+		if p.Epoch != EpochNone && s != "" && strings.Trim(s, "0123456789.-") == "" {
+			if n, _, err := big.ParseFloat(s, 10, 256, big.ToNearestEven); err == nil {
+				return p.epoch(n)
+			}
+		}
+		return time.Time{}, &TimeParseError{Value: s, Layouts: p.Layouts}
+
+	case json.Number:
+		return p.Parse(v.(json.Number).String())
+	}
+
+	if p.Epoch == EpochNone {
+		return time.Time{}, ErrTypeMismatch
+	}
+
+	switch v.(type) {
+	case float32, float64:
+		f, _ := interfaceToFloat64(v, 0)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return time.Time{}, ErrTypeMismatch
+		}
+		return p.epoch(big.NewFloat(f))
+	}
+
+	n, err := interfaceToBigInt(v, nil)
+	if err != nil || !isNumber(v) {
+		return time.Time{}, ErrTypeMismatch
+	}
+	return p.epoch(new(big.Float).SetInt(n))
+}
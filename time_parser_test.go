@@ -0,0 +1,173 @@
+package gmap
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeParser(t *testing.T) {
+	parser := DefaultTimeParser
+	expected := time.Date(2017, time.July, 10, 12, 13, 47, 0, time.UTC)
+
+	for _, v := range []interface{}{
+		"2017-07-10T12:13:47Z",
+		"2017-07-10T14:13:47+02:00",
+	} {
+		tm, err := parser.Parse(v)
+		assert.Nil(t, err)
+		assert.True(t, expected.Equal(tm))
+	}
+
+	// epochs are only read when a unit is set
+	epoch := &TimeParser{Layouts: DefaultTimeLayouts, Epoch: EpochSeconds}
+	for _, v := range []interface{}{
+		1499688827,
+		int64(1499688827),
+		"1499688827",
+		json.Number("1499688827"),
+		1499688827.0,
+	} {
+		tm, err := epoch.Parse(v)
+		assert.Nil(t, err)
+		assert.True(t, expected.Equal(tm))
+
+		_, err = parser.Parse(v)
+		assert.NotNil(t, err)
+	}
+
+	tm, err := parser.Parse("2017-07-10T12:13:47.123+02:00")
+	assert.Nil(t, err)
+	assert.Equal(t, 123*time.Millisecond, time.Duration(tm.Nanosecond()))
+
+	tm, err = parser.Parse("2017-07-10")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, time.July, 10, 0, 0, 0, 0, time.UTC), tm)
+
+	tm, err = epoch.Parse("1499688827.5")
+	assert.Nil(t, err)
+	assert.Equal(t, expected.Add(500*time.Millisecond), tm)
+
+	_, err = parser.Parse("yesterday")
+	assert.True(t, errors.Is(err, ErrInvalidTime))
+	parseErr, ok := err.(*TimeParseError)
+	assert.True(t, ok)
+	assert.Equal(t, "yesterday", parseErr.Value)
+	assert.Equal(t, DefaultTimeLayouts, parseErr.Layouts)
+	assert.True(t, strings.Contains(err.Error(), TimeFormatRFC3339Nano))
+
+	_, err = parser.Parse(true)
+	assert.Equal(t, ErrTypeMismatch, err)
+}
+
+func TestTimeParserEpoch(t *testing.T) {
+	expected := time.Date(2017, time.July, 10, 12, 13, 47, 123000000, time.UTC)
+
+	for unit, v := range map[EpochUnit]interface{}{
+		EpochMilliseconds: int64(1499688827123),
+		EpochMicroseconds: "1499688827123000",
+		EpochNanoseconds:  json.Number("1499688827123000000"),
+	} {
+		parser := &TimeParser{Epoch: unit}
+		tm, err := parser.Parse(v)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, tm)
+
+		parser = &TimeParser{Epoch: EpochAuto}
+		tm, err = parser.Parse(v)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, tm)
+	}
+
+	parser := &TimeParser{}
+	_, err := parser.Parse(1499688827)
+	assert.Equal(t, ErrTypeMismatch, err)
+
+	_, err = parser.Parse("1499688827")
+	assert.True(t, errors.Is(err, ErrInvalidTime))
+}
+
+func TestTimeParserLayouts(t *testing.T) {
+	loc := time.FixedZone("PDT", -7*3600)
+	parser := &TimeParser{
+		Layouts:  []string{"02.01.2006 15:04"},
+		Location: loc,
+		Parsers: []TimeParseFunc{
+			func(value string) (time.Time, error) {
+				if value == "epoch" {
+					return time.Unix(0, 0).UTC(), nil
+				}
+				return time.Time{}, ErrInvalidTime
+			},
+		},
+	}
+
+	tm, err := parser.Parse("10.07.2017 05:13")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, time.July, 10, 5, 13, 0, 0, loc), tm)
+
+	tm, err = parser.Parse("epoch")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Unix(0, 0).UTC(), tm)
+
+	_, err = parser.Parse("2017-07-10T12:13:47Z")
+	assert.Equal(t, []string{"02.01.2006 15:04"}, err.(*TimeParseError).Layouts)
+
+	var gmap Map
+	gmap = Map{"at": "10.07.2017 05:13", "ms": 1499688827123}
+
+	tm, err = gmap.TimeWithParser("at", time.Time{}, parser)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, time.July, 10, 5, 13, 0, 0, loc), tm)
+
+	def := time.Unix(1, 0)
+	tm, err = gmap.Time("at", def)
+	assert.True(t, errors.Is(err, ErrInvalidTime))
+	assert.Equal(t, def, tm)
+
+	tm, err = gmap.TimeWithParser("ms", time.Time{}, &TimeParser{Epoch: EpochMilliseconds})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, time.July, 10, 12, 13, 47, 123000000, time.UTC), tm)
+}
+
+func TestTimeParserLocation(t *testing.T) {
+	loc := time.FixedZone("EDT", -4*3600)
+	parser := &TimeParser{Layouts: DefaultTimeLayouts, Location: loc}
+
+	// a trailing Z is UTC, whatever the Location
+	tm, err := parser.Parse("2017-07-10T12:00:00Z")
+	assert.Nil(t, err)
+	assert.True(t, tm.Equal(time.Date(2017, time.July, 10, 12, 0, 0, 0, time.UTC)))
+
+	tm, err = parser.Parse("2017-07-10T12:00:00+02:00")
+	assert.Nil(t, err)
+	assert.True(t, tm.Equal(time.Date(2017, time.July, 10, 10, 0, 0, 0, time.UTC)))
+
+	tm, err = parser.Parse("10/Jul/2017:12:00:00 -0700")
+	assert.Nil(t, err)
+	assert.True(t, tm.Equal(time.Date(2017, time.July, 10, 19, 0, 0, 0, time.UTC)))
+
+	// zone-less layouts use the Location
+	tm, err = parser.Parse("2017-07-10")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, time.July, 10, 0, 0, 0, 0, loc), tm)
+}
+
+func TestRegisterTimeParser(t *testing.T) {
+	parsers := DefaultTimeParser.Parsers
+	defer func() { DefaultTimeParser.Parsers = parsers }()
+
+	RegisterTimeParser(func(value string) (time.Time, error) {
+		return time.Parse("2006/01/02", value)
+	})
+
+	var gmap Map
+	gmap = Map{"day": "2017/07/10"}
+	tm, err := gmap.Time("day", time.Time{})
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2017, time.July, 10, 0, 0, 0, 0, time.UTC), tm)
+}
@@ -0,0 +1,290 @@
+package gmap
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Tracker wraps a Map and records which keys are read through its getters, so that keys nobody reads,
+// such as a misspelled 'timout' in a config file, can be reported by Unused.
+// Nested Maps read with Map are Trackers too, and record the full paths of their keys.
+//
+//	t := gmap.Track(config)
+//	timeout, _ := t.Duration("timeout", 30*time.Second)
+//	db, _ := t.Map("database", nil)
+//	host, _ := db.String("host", "localhost")
+//	for _, u := range t.Unused() {
+//		log.Printf("unknown config key %s", u)
+//	}
+type Tracker struct {
+	m      Map
+	path   string
+	access *access
+}
+
+// Keys read by a Tracker and its nested Trackers.
+type access struct {
+	root Map
+	// paths of values that were read, and of Maps that were read with Map
+	read   map[string]bool
+	nested map[string]bool
+	// keys that were requested but did not exist, by the path of their Map
+	missing map[string]map[string]bool
+}
+
+// UnusedKey is a key that was never read from a Tracker.
+type UnusedKey struct {
+	// Path is the full path of the key, e.g. 'database.timout'.
+	Path string
+	// Suggestion is the path of a key that was requested but missing, and is spelled similarly,
+	// e.g. 'database.timeout'. Empty if there is none.
+	Suggestion string
+}
+
+// Formats the key as 'database.timout', or 'database.timout (did you mean database.timeout?)'.
+func (u UnusedKey) String() string {
+	if u.Suggestion == "" {
+		return u.Path
+	}
+	return u.Path + " (did you mean " + u.Suggestion + "?)"
+}
+
+// UnusedOptions configures how UnusedWithOptions suggests keys.
+type UnusedOptions struct {
+	// MaxDistance is the largest number of edits between an unused key and a missing key for the
+	// missing key to be suggested. Zero disables suggestions.
+	MaxDistance int
+}
+
+// Returns a new Tracker that records which keys of m are read.
+func Track(m Map) *Tracker {
+	return &Tracker{
+		m: m,
+		access: &access{
+			root:    m,
+			read:    map[string]bool{},
+			nested:  map[string]bool{},
+			missing: map[string]map[string]bool{},
+		},
+	}
+}
+
+// Returns the Map being tracked.
+// Reading it directly does not record any keys.
+func (t *Tracker) Unwrap() Map {
+	return t.m
+}
+
+// Records that key was requested, given the error of the getter that read it.
+func (t *Tracker) touch(key string, err error) {
+	if errors.Is(err, ErrKeyDoesNotExist) {
+		if t.access.missing[t.path] == nil {
+			t.access.missing[t.path] = map[string]bool{}
+		}
+		t.access.missing[t.path][key] = true
+		return
+	}
+	t.access.read[joinPath(t.path, key)] = true
+}
+
+// Retrieves a nested Map as a Tracker, so that the keys read from it are recorded too.
+// Returns a Tracker of def and an error if key does not exist, is nil or is not a Map.
+func (t *Tracker) Map(key string, def Map) (*Tracker, error) {
+	v, err := t.m.Map(key, def)
+	t.touch(key, err)
+
+	path := joinPath(t.path, key)
+	if err == nil {
+		t.access.nested[path] = true
+	}
+	return &Tracker{m: v, path: path, access: t.access}, err
+}
+
+// Retrieves an array of interface{}. See Map.Array.
+func (t *Tracker) Array(key string, def []interface{}) ([]interface{}, error) {
+	v, err := t.m.Array(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves an int. See Map.Int.
+func (t *Tracker) Int(key string, def int) (int, error) {
+	v, err := t.m.Int(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves an int64. See Map.Int64.
+func (t *Tracker) Int64(key string, def int64) (int64, error) {
+	v, err := t.m.Int64(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a uint64. See Map.Uint64.
+func (t *Tracker) Uint64(key string, def uint64) (uint64, error) {
+	v, err := t.m.Uint64(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a float64. See Map.Float.
+func (t *Tracker) Float(key string, def float64) (float64, error) {
+	v, err := t.m.Float(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a *big.Int. See Map.BigInt.
+func (t *Tracker) BigInt(key string, def *big.Int) (*big.Int, error) {
+	v, err := t.m.BigInt(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a Decimal. See Map.Decimal.
+func (t *Tracker) Decimal(key string, def Decimal) (Decimal, error) {
+	v, err := t.m.Decimal(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a string. See Map.String.
+func (t *Tracker) String(key string, def string) (string, error) {
+	v, err := t.m.String(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a bool. See Map.Boolean.
+func (t *Tracker) Boolean(key string, def bool) (bool, error) {
+	v, err := t.m.Boolean(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves an array of strings. See Map.StringArray.
+func (t *Tracker) StringArray(key string, def []string) ([]string, error) {
+	v, err := t.m.StringArray(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves an array of float64. See Map.FloatArray.
+func (t *Tracker) FloatArray(key string, def []float64) ([]float64, error) {
+	v, err := t.m.FloatArray(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves an array of int. See Map.IntArray.
+func (t *Tracker) IntArray(key string, def []int) ([]int, error) {
+	v, err := t.m.IntArray(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a time.Time. See Map.Time.
+func (t *Tracker) Time(key string, def time.Time) (time.Time, error) {
+	v, err := t.m.Time(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a time.Duration. See Map.Duration.
+func (t *Tracker) Duration(key string, def time.Duration) (time.Duration, error) {
+	v, err := t.m.Duration(key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Retrieves a value of any type from a Tracker, the same way as Get, and records the key as read.
+func GetTracked[T any](t *Tracker, key string, def T) (T, error) {
+	v, err := Get(t.m, key, def)
+	t.touch(key, err)
+	return v, err
+}
+
+// Returns the keys of the tracked Map that were never read, sorted by path, with suggestions
+// for keys that are at most 2 edits away from a key that was requested but missing.
+// Nested Maps that were read with Map are reported key by key, other values as a whole.
+func (t *Tracker) Unused() []UnusedKey {
+	return t.UnusedWithOptions(UnusedOptions{MaxDistance: 2})
+}
+
+// Returns the keys of the tracked Map that were never read, suggesting missing keys as specified by opts.
+func (t *Tracker) UnusedWithOptions(opts UnusedOptions) []UnusedKey {
+	unused := []UnusedKey{}
+	t.access.collect("", t.access.root, opts, &unused)
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].Path < unused[j].Path
+	})
+	return unused
+}
+
+// Appends the unused keys of mp, which is found at path, to unused.
+func (a *access) collect(path string, mp Map, opts UnusedOptions, unused *[]UnusedKey) {
+	for k, v := range mp {
+		p := joinPath(path, k)
+		if a.nested[p] {
+			if nested, err := interfaceToMap(v, nil); err == nil {
+				a.collect(p, nested, opts, unused)
+				continue
+			}
+		}
+		if a.read[p] {
+			continue
+		}
+
+		u := UnusedKey{Path: p}
+		if suggestion := a.suggest(path, k, opts.MaxDistance); suggestion != "" {
+			u.Suggestion = joinPath(path, suggestion)
+		}
+		*unused = append(*unused, u)
+	}
+}
+
+// Finds the missing key of the Map at path that is spelled most like key, within maxDistance edits.
+// Ties are broken alphabetically.
+func (a *access) suggest(path, key string, maxDistance int) string {
+	best, bestDistance := "", maxDistance+1
+	for missing := range a.missing[path] {
+		d := editDistance(key, missing)
+		if d < bestDistance || (d == bestDistance && missing < best) {
+			best, bestDistance = missing, d
+		}
+	}
+	return best
+}
+
+// Returns the Levenshtein distance between a and b, the number of single character insertions,
+// deletions and substitutions needed to turn one into the other.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,79 @@
+package gmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("timeout", "timeout"))
+	assert.Equal(t, 1, editDistance("timout", "timeout"))
+	assert.Equal(t, 2, editDistance("hots", "host"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 4, editDistance("", "port"))
+	assert.Equal(t, 1, editDistance("über", "uber"))
+}
+
+func TestTracker(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"timout":  "30s",
+		"verbose": true,
+		"name":    "api",
+		"database": map[string]interface{}{
+			"host": "localhost",
+			"prot": 5432,
+		},
+		"cache": Map{"size": 10},
+	}
+
+	tr := Track(gmap)
+	timeout, err := tr.Duration("timeout", time.Minute)
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	assert.Equal(t, time.Minute, timeout)
+
+	name, err := GetTracked(tr, "name", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "api", name)
+
+	db, err := tr.Map("database", nil)
+	assert.Nil(t, err)
+	host, err := db.String("host", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", host)
+	port, _ := db.Int("port", 5432)
+	assert.Equal(t, 5432, port)
+
+	missing, err := tr.Map("metrics", Map{"enabled": true})
+	assert.True(t, errors.Is(err, ErrKeyDoesNotExist))
+	enabled, err := missing.Boolean("enabled", false)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	unused := tr.Unused()
+	assert.Equal(t, []UnusedKey{
+		{Path: "cache"},
+		{Path: "database.prot", Suggestion: "database.port"},
+		{Path: "timout", Suggestion: "timeout"},
+		{Path: "verbose"},
+	}, unused)
+	assert.Equal(t, "timout (did you mean timeout?)", unused[2].String())
+	assert.Equal(t, "cache", unused[0].String())
+
+	unused = tr.UnusedWithOptions(UnusedOptions{})
+	assert.Equal(t, "", unused[2].Suggestion)
+
+	// reading a nested Map without reading its keys reports them
+	_, _ = tr.Map("cache", nil)
+	assert.Equal(t, "cache.size", tr.Unused()[0].Path)
+
+	tr = Track(Map{"a": 1})
+	_, err = tr.String("a", "")
+	assert.Nil(t, err)
+	assert.Empty(t, tr.Unused())
+	assert.Equal(t, Map{"a": 1}, tr.Unwrap())
+}
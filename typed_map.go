@@ -0,0 +1,198 @@
+package gmap
+
+import (
+	"reflect"
+	"sort"
+)
+
+// TypedMap provides the collection functions of Map for maps whose values all have the same type,
+// such as map[string]string or map[string]float64.
+type TypedMap[V any] map[string]V
+
+type TypedFilterFunc[V any] func(k string, v V) bool
+
+type TypedReduceFunc[V, R any] func(memo R, k string, v V) R
+
+type TypedMergeFunc[V any] func(k string, oldValue, newValue V) V
+
+// Converts a Map to a TypedMap, converting each value the same way as Get.
+// Nil values become the zero value of V if V can be nil, such as a pointer or an interface.
+// Returns an *Error naming the first key, in sorted order, whose value is nil or cannot be converted.
+func ToTypedMap[V any](m Map) (TypedMap[V], error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var zero V
+	target := reflect.TypeOf(&zero).Elem().String()
+	nillable := false
+	switch reflect.TypeOf(&zero).Elem().Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		nillable = true
+	}
+
+	tm := make(TypedMap[V], len(m))
+	for _, k := range keys {
+		if m[k] == nil {
+			if !nillable {
+				return nil, newKeyError(k, target, ErrNilValue)
+			}
+			tm[k] = zero
+			continue
+		}
+
+		v, err := interfaceToType(m[k], zero)
+		if err != nil {
+			return nil, newValueError(k, target, m[k], err)
+		}
+		tm[k] = v
+	}
+	return tm, nil
+}
+
+// Converts the map to a Map.
+// Returns a new Map.
+func (m TypedMap[V]) Map() Map {
+	mp := make(Map, len(m))
+	for k, v := range m {
+		mp[k] = v
+	}
+	return mp
+}
+
+// Slice returns a new TypedMap with only the given keys.
+// Opposite of Except.
+func (m TypedMap[V]) Slice(keys ...string) TypedMap[V] {
+	mp := TypedMap[V]{}
+	for _, k := range keys {
+		if v, ok := m[k]; ok {
+			mp[k] = v
+		}
+	}
+
+	return mp
+}
+
+// Except returns a new TypedMap except the given keys.
+// Opposite of Slice.
+func (m TypedMap[V]) Except(keys ...string) TypedMap[V] {
+	mp := TypedMap[V]{}
+	for k, v := range m {
+		mp[k] = v
+	}
+
+	for _, k := range keys {
+		delete(mp, k)
+	}
+
+	return mp
+}
+
+// Invokes selectFn for each k,v pair in the map, keeping elements for which the function returns true.
+// Opposite of Reject().
+func (m TypedMap[V]) Select(selectFn TypedFilterFunc[V]) TypedMap[V] {
+	if selectFn == nil {
+		return m
+	}
+
+	result := TypedMap[V]{}
+	for k, v := range m {
+		if selectFn(k, v) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Invokes rejectFn for each k,v pair in the map, deleting elements for which the function returns true.
+// Opposite of Select().
+func (m TypedMap[V]) Reject(rejectFn TypedFilterFunc[V]) TypedMap[V] {
+	if rejectFn == nil {
+		return m
+	}
+
+	result := TypedMap[V]{}
+	for k, v := range m {
+		if !rejectFn(k, v) {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// Combines all entries of map by applying reduceFn, the same way as Map.Reduce.
+// Use ReduceTyped to reduce to a value of a different type.
+// Returns the final memo result.
+func (m TypedMap[V]) Reduce(initial V, reduceFn TypedReduceFunc[V, V]) V {
+	return ReduceTyped(m, initial, reduceFn)
+}
+
+// Combines all entries of a TypedMap by applying reduceFn, the same way as Map.Reduce,
+// e.g. counting the entries of a TypedMap[string] into an int.
+// Returns the final memo result.
+func ReduceTyped[V, R any](m TypedMap[V], initial R, reduceFn TypedReduceFunc[V, R]) R {
+	if reduceFn == nil {
+		return initial
+	}
+
+	memo := initial
+	for k, v := range m {
+		memo = reduceFn(memo, k, v)
+	}
+	return memo
+}
+
+// Merges this TypedMap with another TypedMap.
+// Entries with key collisions are overwritten with the values from other TypedMap.
+// Returns a new TypedMap.
+func (m TypedMap[V]) Merge(other TypedMap[V]) TypedMap[V] {
+	return m.MergeWithFunc(other, func(k string, oldValue, newValue V) V {
+		return newValue
+	})
+}
+
+// Merges this TypedMap with another TypedMap with a custom merge function.
+// Returns a new TypedMap.
+func (m TypedMap[V]) MergeWithFunc(other TypedMap[V], mergeFn TypedMergeFunc[V]) TypedMap[V] {
+	mp := TypedMap[V]{}
+	for k, v := range m {
+		mp[k] = v
+	}
+
+	for k, v := range other {
+		if mval, ok := mp[k]; ok {
+			mp[k] = mergeFn(k, mval, v)
+		} else {
+			mp[k] = v
+		}
+	}
+
+	return mp
+}
+
+// Retrieves the values of the given keys, using the zero value of V for missing keys.
+// If no keys are given, returns the entire map.
+func (m TypedMap[V]) Values(keys ...string) []V {
+	values := make([]V, 0)
+	if len(keys) == 0 {
+		for _, v := range m {
+			values = append(values, v)
+		}
+	} else {
+		for _, k := range keys {
+			values = append(values, m[k])
+		}
+	}
+	return values
+}
+
+// Retrieves the keys in the map.
+func (m TypedMap[V]) Keys() []string {
+	keys := make([]string, 0)
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,60 @@
+package gmap
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedMapCollection(t *testing.T) {
+	prices := TypedMap[float64]{"apple": 1.5, "pear": 2.0, "melon": 4.5}
+
+	assert.Equal(t, TypedMap[float64]{"apple": 1.5}, prices.Slice("apple", "kiwi"))
+	assert.Equal(t, TypedMap[float64]{"pear": 2.0, "melon": 4.5}, prices.Except("apple"))
+
+	cheap := prices.Select(func(k string, v float64) bool { return v < 3 })
+	assert.Equal(t, TypedMap[float64]{"apple": 1.5, "pear": 2.0}, cheap)
+
+	expensive := prices.Reject(func(k string, v float64) bool { return v < 3 })
+	assert.Equal(t, TypedMap[float64]{"melon": 4.5}, expensive)
+
+	total := prices.Reduce(0, func(memo float64, k string, v float64) float64 { return memo + v })
+	assert.Equal(t, 8.0, total)
+
+	letters := ReduceTyped(prices, 0, func(memo int, k string, v float64) int { return memo + len(k) })
+	assert.Equal(t, 14, letters)
+
+	merged := prices.Merge(TypedMap[float64]{"pear": 3.0, "kiwi": 1.0})
+	assert.Equal(t, TypedMap[float64]{"apple": 1.5, "pear": 3.0, "melon": 4.5, "kiwi": 1.0}, merged)
+	assert.Equal(t, 2.0, prices["pear"])
+
+	summed := prices.MergeWithFunc(TypedMap[float64]{"pear": 3.0}, func(k string, oldValue, newValue float64) float64 {
+		return oldValue + newValue
+	})
+	assert.Equal(t, 5.0, summed["pear"])
+
+	keys := prices.Keys()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"apple", "melon", "pear"}, keys)
+	assert.Equal(t, []float64{1.5, 0}, prices.Values("apple", "kiwi"))
+}
+
+func TestToTypedMap(t *testing.T) {
+	tm, err := ToTypedMap[string](Map{"name": "John", "age": 30, "admin": true})
+	assert.Nil(t, err)
+	assert.Equal(t, TypedMap[string]{"name": "John", "age": "30", "admin": "true"}, tm)
+	assert.Equal(t, Map{"name": "John", "age": "30", "admin": "true"}, tm.Map())
+
+	nums, err := ToTypedMap[float64](Map{"a": "1.5", "b": 2, "c": "x"})
+	assert.Nil(t, nums)
+	assert.Equal(t, "c", err.(*Error).Key)
+
+	_, err = ToTypedMap[int](Map{"a": nil})
+	assert.True(t, errors.Is(err, ErrNilValue))
+
+	anything, err := ToTypedMap[interface{}](Map{"a": nil, "b": 1})
+	assert.Nil(t, err)
+	assert.Equal(t, TypedMap[interface{}]{"a": nil, "b": 1}, anything)
+}
@@ -0,0 +1,377 @@
+package gmap
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FromUrlValuesOptions configures how FromUrlValuesWithOptions and FromUrlValuesStrict read url.Values.
+type FromUrlValuesOptions struct {
+	// IndexedMaps keeps numeric keys such as 'items[0]' as Map keys instead of creating arrays,
+	// and reads 'tags[]' the same as 'tags', as FromUrlValues used to.
+	IndexedMaps bool
+	// MaxDepth limits how many subkeys a key may have, e.g. 'a[b][c]' has a depth of 3. Zero means no limit.
+	MaxDepth int
+	// MaxKeys limits how many keys may be read. Zero means no limit.
+	MaxKeys int
+}
+
+// FormError lists every key of url.Values that was rejected by FromUrlValuesStrict.
+// Each entry is a *PathError whose Path is the key as it was sent.
+type FormError struct {
+	Errors []error
+}
+
+func (e *FormError) Error() string {
+	return formatErrors("gmap form values rejected", e.Errors)
+}
+
+// Unwrap returns the errors of every rejected key, so errors.Is matches any of them.
+func (e *FormError) Unwrap() []error {
+	return e.Errors
+}
+
+func isBracket(c rune) bool {
+	return c == '[' || c == ']'
+}
+
+// Splits a form key such as 'items[0][name]' or 'tags[]' into its subkeys.
+// Empty brackets become empty subkeys.
+func splitFormKey(key string) []string {
+	i := strings.IndexByte(key, '[')
+	if i <= 0 {
+		return strings.FieldsFunc(key, isBracket)
+	}
+
+	subkeys := []string{key[:i]}
+	rest := key[i:]
+	for len(rest) > 0 && rest[0] == '[' {
+		j := strings.IndexByte(rest, ']')
+		if j < 0 {
+			break
+		}
+		subkeys = append(subkeys, rest[1:j])
+		rest = rest[j+1:]
+	}
+	return append(subkeys, strings.FieldsFunc(rest, isBracket)...)
+}
+
+// Retrieves the nested Map at subkeys, creating Maps as needed.
+// Returns a *PathError wrapping ErrKeyConflict if a value had to be replaced with a Map.
+func formMap(key string, m Map, subkeys []string) (Map, error) {
+	var err error
+	submap := m
+	for _, subkey := range subkeys {
+		var mp Map
+		if submap[subkey] == nil {
+			mp = Map{}
+			submap[subkey] = mp
+		} else {
+			// if there already exists a key but has a different type than a map
+			// then we overwrite that value and replace it with a Map
+			switch submap[subkey].(type) {
+			case Map:
+				mp = submap[subkey].(Map)
+			default:
+				mp = Map{}
+				submap[subkey] = mp
+				if err == nil {
+					err = &PathError{Path: key, Segment: subkey, Err: ErrKeyConflict}
+				}
+			}
+		}
+		submap = mp
+	}
+	return submap, err
+}
+
+// Stores form values under nested subkeys.
+// Single-element string arrays will be unpacked to regular strings.
+// Returns a *PathError wrapping ErrKeyConflict if an existing value was replaced.
+func setFormValue(key string, m Map, subkeys []string, v []string) error {
+	lastIndex := len(subkeys) - 1
+	submap, err := formMap(key, m, subkeys[:lastIndex])
+	if _, exists := submap[subkeys[lastIndex]]; exists && err == nil {
+		err = &PathError{Path: key, Segment: subkeys[lastIndex], Err: ErrKeyConflict}
+	}
+
+	submap[subkeys[lastIndex]] = v
+	if len(v) == 1 {
+		submap[subkeys[lastIndex]] = v[0]
+	}
+	return err
+}
+
+// Stores form values as new elements of the nested array at subkeys.
+func appendFormValues(key string, m Map, subkeys []string, v []string) error {
+	submap, err := formMap(key, m, subkeys)
+	next := 0
+	for k := range submap {
+		if i, ok := formIndex(k); ok && i >= next {
+			next = i + 1
+		}
+	}
+
+	for i, s := range v {
+		submap[strconv.Itoa(next+i)] = s
+	}
+	return err
+}
+
+// Parses a subkey that is an array index, such as '0' but not '00' or '-1'.
+func formIndex(subkey string) (int, bool) {
+	i, err := strconv.Atoi(subkey)
+	return i, err == nil && i >= 0 && strconv.Itoa(i) == subkey
+}
+
+// Converts nested Maps into arrays when all of their keys are array indices.
+// Elements are ordered by index, and missing indices are left out.
+func formArrays(value interface{}) interface{} {
+	mp, ok := value.(Map)
+	if !ok {
+		return value
+	}
+
+	indices := make([]int, 0, len(mp))
+	for k, v := range mp {
+		mp[k] = formArrays(v)
+		if i, ok := formIndex(k); ok {
+			indices = append(indices, i)
+		}
+	}
+
+	if len(indices) == 0 || len(indices) != len(mp) {
+		return mp
+	}
+
+	sort.Ints(indices)
+	arr := make([]interface{}, len(indices))
+	for j, i := range indices {
+		arr[j] = mp[strconv.Itoa(i)]
+	}
+	return arr
+}
+
+// Stores the values of a single form key, split into subkeys.
+func addFormValues(key string, m Map, subkeys []string, v []string) error {
+	empty := -1
+	for i, subkey := range subkeys {
+		if subkey == "" && i > 0 {
+			empty = i
+			break
+		}
+	}
+
+	switch {
+	case empty < 0:
+		return setFormValue(key, m, subkeys, v)
+
+	case empty == len(subkeys)-1:
+		return appendFormValues(key, m, subkeys[:empty], v)
+
+	default:
+		var err error
+		for i, s := range v {
+			indexed := make([]string, len(subkeys))
+			for j, subkey := range subkeys {
+				if subkey == "" && j > 0 {
+					subkey = "0"
+				}
+				indexed[j] = subkey
+			}
+			indexed[empty] = strconv.Itoa(i)
+			if e := setFormValue(key, m, indexed, []string{s}); err == nil {
+				err = e
+			}
+		}
+		return err
+	}
+}
+
+// Builds a new Map from url.Values, reading keys in sorted order.
+// If more is not nil, it is called to add other keys, such as uploaded files, before nested Maps are turned into arrays.
+// Keys deeper than opts.MaxDepth are skipped. Returns every rejected key.
+func buildFormValues(values url.Values, opts FromUrlValuesOptions, more func(Map) []error) (Map, []error) {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	built := Map{}
+	for _, k := range keys {
+		var subkeys []string
+		if opts.IndexedMaps {
+			subkeys = strings.FieldsFunc(k, isBracket)
+		} else {
+			subkeys = splitFormKey(k)
+		}
+		if len(subkeys) == 0 {
+			subkeys = []string{k}
+		}
+
+		if opts.MaxDepth > 0 && len(subkeys) > opts.MaxDepth {
+			errs = append(errs, &PathError{Path: k, Segment: subkeys[opts.MaxDepth], Err: ErrTooDeep})
+			continue
+		}
+
+		var err error
+		if opts.IndexedMaps {
+			err = setFormValue(k, built, subkeys, values[k])
+		} else {
+			err = addFormValues(k, built, subkeys, values[k])
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if more != nil {
+		errs = append(errs, more(built)...)
+	}
+
+	if !opts.IndexedMaps {
+		for k, v := range built {
+			built[k] = formArrays(v)
+		}
+	}
+	return built, errs
+}
+
+// Fills map with values from url.Values the same way as FromUrlValues, as configured by opts.
+// Keys are read in sorted order, and 'items[][name]' gives the n-th value to the n-th element of 'items'.
+// Keys nested deeper than opts.MaxDepth are skipped, and nothing is read if there are more than opts.MaxKeys keys.
+func (m Map) FromUrlValuesWithOptions(values url.Values, opts FromUrlValuesOptions) {
+	m.fromForm(values, opts, false, 0, nil)
+}
+
+// Fills map with values from url.Values the same way as FromUrlValuesWithOptions, but rejects conflicting keys
+// instead of overwriting them, e.g. 'foo=1' together with 'foo[bar]=2'.
+// Returns ErrTooManyKeys if there are more than opts.MaxKeys keys, or a *FormError listing every key that
+// conflicts or is nested deeper than opts.MaxDepth. The map is left unchanged when an error is returned.
+func (m Map) FromUrlValuesStrict(values url.Values, opts FromUrlValuesOptions) error {
+	return m.fromForm(values, opts, true, 0, nil)
+}
+
+// Fills map with values from url.Values, and with the extra keys added by more, which count towards opts.MaxKeys.
+// If strict, rejected keys are returned as a *FormError and the map is left unchanged,
+// otherwise they are ignored the same way as FromUrlValuesWithOptions.
+func (m Map) fromForm(values url.Values, opts FromUrlValuesOptions, strict bool, extra int, more func(Map) []error) error {
+	if opts.MaxKeys > 0 && len(values)+extra > opts.MaxKeys {
+		if strict {
+			return ErrTooManyKeys
+		}
+		return nil
+	}
+
+	built, errs := buildFormValues(values, opts, more)
+	if strict && len(errs) > 0 {
+		return &FormError{Errors: errs}
+	}
+
+	for k, v := range m.DeepMerge(built) {
+		m[k] = v
+	}
+	return nil
+}
+
+// UrlValuesOptions configures how ToUrlValuesWithOptions encodes a Map.
+type UrlValuesOptions struct {
+	// ArrayBrackets writes arrays of plain values as 'foo[]=a&foo[]=b' instead of 'foo=a&foo=b'.
+	ArrayBrackets bool
+	// TimeFormat is the layout used for time.Time values. Defaults to TimeFormatISO8601, which is written in UTC.
+	TimeFormat string
+}
+
+// Converts a plain value to the string sent in url.Values.
+func (o UrlValuesOptions) format(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	if t, ok := value.(time.Time); ok {
+		layout := o.TimeFormat
+		if layout == "" {
+			layout = TimeFormatISO8601
+		}
+		// the ISO8601 layout ends with a literal Z, so it is only correct for UTC
+		if layout == TimeFormatISO8601 {
+			t = t.UTC()
+		}
+		return t.Format(layout)
+	}
+
+	if s, err := interfaceToString(value, ""); err == nil {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// Adds value, found at key, to values.
+// Elements of arrays are always written with brackets, e.g. 'foo[0][]=a', so they are read back as arrays.
+func (o UrlValuesOptions) encode(key string, value interface{}, element bool, values url.Values) {
+	if mp, err := interfaceToMap(value, nil); err == nil {
+		for k, v := range mp {
+			o.encode(key+"["+k+"]", v, false, values)
+		}
+		return
+	}
+
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8 {
+		// arrays of Maps or arrays need an index to keep their elements apart
+		indexed := false
+		for i := 0; i < rv.Len(); i++ {
+			e := rv.Index(i).Interface()
+			if _, err := interfaceToMap(e, nil); err == nil {
+				indexed = true
+			}
+			if k := reflect.ValueOf(e).Kind(); k == reflect.Slice || k == reflect.Array {
+				indexed = true
+			}
+		}
+
+		// a single value without brackets would be read back as a plain value
+		brackets := o.ArrayBrackets || element || rv.Len() == 1
+		for i := 0; i < rv.Len(); i++ {
+			e := rv.Index(i).Interface()
+			switch {
+			case indexed:
+				o.encode(key+"["+strconv.Itoa(i)+"]", e, true, values)
+			case brackets:
+				values.Add(key+"[]", o.format(e))
+			default:
+				values.Add(key, o.format(e))
+			}
+		}
+		return
+	}
+
+	values.Add(key, o.format(value))
+}
+
+// Converts the map to url.Values, the inverse of FromUrlValues.
+// Nested Maps are written with bracketed keys such as 'foo[bar]', and time.Time values are written in UTC
+// using TimeFormatISO8601. Arrays repeat their key, except that arrays with a single element and arrays
+// nested in other arrays are written with brackets, e.g. 'foo[]=a' and 'foo[0][]=a', so they stay arrays.
+// Maps of strings and arrays survive a round trip through FromUrlValues, with arrays of two or more strings
+// read back as []string and other arrays as []interface{}. Empty arrays are left out, and keys
+// that contain brackets are not escaped, so they are read back as nested keys.
+func (m Map) ToUrlValues() url.Values {
+	return m.ToUrlValuesWithOptions(UrlValuesOptions{})
+}
+
+// Converts the map to url.Values the same way as ToUrlValues, as configured by opts.
+func (m Map) ToUrlValuesWithOptions(opts UrlValuesOptions) url.Values {
+	values := url.Values{}
+	for k, v := range m {
+		opts.encode(k, v, false, values)
+	}
+	return values
+}
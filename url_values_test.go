@@ -0,0 +1,175 @@
+package gmap
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToUrlValues(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"foo":     "bar",
+		"count":   10,
+		"flag":    true,
+		"nothing": nil,
+		"created": time.Date(2017, time.July, 10, 5, 13, 47, 0, time.FixedZone("PDT", -7*3600)),
+		"hello":   []string{"bar", "chomp"},
+		"mixed":   []interface{}{1, "two"},
+		"nested": Map{
+			"map":  "what",
+			"even": map[string]interface{}{"deeper": "easy there"},
+		},
+		"rows": []interface{}{
+			Map{"name": "a"},
+			Map{"name": "b"},
+		},
+	}
+
+	uv := gmap.ToUrlValues()
+	assert.Equal(t, url.Values{
+		"foo":                  []string{"bar"},
+		"count":                []string{"10"},
+		"flag":                 []string{"true"},
+		"nothing":              []string{""},
+		"created":              []string{"2017-07-10T12:13:47Z"},
+		"hello":                []string{"bar", "chomp"},
+		"mixed":                []string{"1", "two"},
+		"nested[map]":          []string{"what"},
+		"nested[even][deeper]": []string{"easy there"},
+		"rows[0][name]":        []string{"a"},
+		"rows[1][name]":        []string{"b"},
+	}, uv)
+
+	uv = gmap.ToUrlValuesWithOptions(UrlValuesOptions{ArrayBrackets: true, TimeFormat: TimeFormatRFC1123})
+	assert.Equal(t, []string{"bar", "chomp"}, uv["hello[]"])
+	assert.Equal(t, []string{"Mon, 10 Jul 2017 05:13:47 PDT"}, uv["created"])
+	assert.Equal(t, []string{"a"}, uv["rows[0][name]"])
+}
+
+func TestToUrlValuesRoundTrip(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"foo":   "bar",
+		"hello": []string{"bar", "chomp", "bit"},
+		"nested": Map{
+			"map":  "what",
+			"even": Map{"deeper": "easy there"},
+		},
+	}
+
+	result := Map{}
+	result.FromUrlValues(gmap.ToUrlValues())
+	assert.Equal(t, gmap, result)
+}
+
+func TestToUrlValuesRoundTripArrays(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{
+		"tags":  []string{"a"},
+		"grid":  []interface{}{[]string{"a", "b"}, []string{"c"}},
+		"names": []string{"x", "y"},
+	}
+
+	uv := gmap.ToUrlValues()
+	assert.Equal(t, []string{"a"}, uv["tags[]"])
+	assert.Equal(t, []string{"a", "b"}, uv["grid[0][]"])
+	assert.Equal(t, []string{"c"}, uv["grid[1][]"])
+	assert.Equal(t, []string{"x", "y"}, uv["names"])
+
+	result := Map{}
+	result.FromUrlValues(uv)
+	assert.Equal(t, Map{
+		"tags":  []interface{}{"a"},
+		"grid":  []interface{}{[]interface{}{"a", "b"}, []interface{}{"c"}},
+		"names": []string{"x", "y"},
+	}, result)
+}
+
+func TestFromUrlValuesIndexedMaps(t *testing.T) {
+	var gmap Map
+
+	uv := url.Values{}
+	uv["items[0][name]"] = []string{"a"}
+	uv["tags[]"] = []string{"x", "y"}
+
+	gmap = Map{}
+	gmap.FromUrlValuesWithOptions(uv, FromUrlValuesOptions{IndexedMaps: true})
+	assert.Equal(t, Map{"0": Map{"name": "a"}}, gmap["items"])
+	assert.Equal(t, []string{"x", "y"}, gmap["tags"])
+}
+
+func TestFromUrlValuesMergesExisting(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{"user": Map{"id": 1}, "keep": true}
+	gmap.FromUrlValues(url.Values{"user[name]": []string{"John"}})
+	assert.Equal(t, Map{"id": 1, "name": "John"}, gmap["user"])
+	assert.Equal(t, true, gmap["keep"])
+}
+
+func TestFromUrlValuesStrict(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{"keep": true}
+	err := gmap.FromUrlValuesStrict(url.Values{
+		"user[name]":   []string{"John"},
+		"user[tags][]": []string{"a", "b"},
+	}, FromUrlValuesOptions{MaxDepth: 3, MaxKeys: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, Map{
+		"keep": true,
+		"user": Map{"name": "John", "tags": []interface{}{"a", "b"}},
+	}, gmap)
+
+	gmap = Map{"keep": true}
+	err = gmap.FromUrlValuesStrict(url.Values{
+		"foo":         []string{"1"},
+		"foo[bar]":    []string{"2"},
+		"rows[0][id]": []string{"1"},
+		"rows[][id]":  []string{"2"},
+		"a[b][c][d]":  []string{"deep"},
+		"ok":          []string{"fine"},
+	}, FromUrlValuesOptions{MaxDepth: 3})
+	assert.Equal(t, Map{"keep": true}, gmap)
+
+	formErr, ok := err.(*FormError)
+	assert.True(t, ok)
+	assert.Len(t, formErr.Errors, 3)
+	assert.True(t, errors.Is(err, ErrKeyConflict))
+	assert.True(t, errors.Is(err, ErrTooDeep))
+	assert.Equal(t, "a[b][c][d]", formErr.Errors[0].(*PathError).Path)
+	assert.Equal(t, "foo[bar]", formErr.Errors[1].(*PathError).Path)
+	assert.Equal(t, "rows[][id]", formErr.Errors[2].(*PathError).Path)
+
+	err = gmap.FromUrlValuesStrict(url.Values{"a": nil, "b": nil, "c": nil}, FromUrlValuesOptions{MaxKeys: 2})
+	assert.Equal(t, ErrTooManyKeys, err)
+}
+
+func TestFromUrlValuesLimits(t *testing.T) {
+	var gmap Map
+
+	gmap = Map{}
+	gmap.FromUrlValuesWithOptions(url.Values{
+		"a[b][c]": []string{"deep"},
+		"ok":      []string{"fine"},
+	}, FromUrlValuesOptions{MaxDepth: 2})
+	assert.Equal(t, Map{"ok": "fine"}, gmap)
+
+	gmap = Map{}
+	gmap.FromUrlValuesWithOptions(url.Values{"a": nil, "b": nil}, FromUrlValuesOptions{MaxKeys: 1})
+	assert.Equal(t, Map{}, gmap)
+
+	// the value sent with the deeper key wins, whatever order the keys are in
+	for i := 0; i < 10; i++ {
+		gmap = Map{}
+		gmap.FromUrlValues(url.Values{"foo": []string{"1"}, "foo[bar]": []string{"2"}})
+		assert.Equal(t, Map{"foo": Map{"bar": "2"}}, gmap)
+	}
+}